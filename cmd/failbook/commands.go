@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+const usage = `Usage: failbook [command] [options]
+
+Commands:
+  serve      Start the HTTP server (default)
+  openapi    Export the problem catalog as an OpenAPI 3.1 document
+  import     Generate problem YAML files from an OpenAPI 3.x document
+  export     Render the documentation as a static site
+  lint       Check problem YAML files and report findings with their positions
+  validate   Check problem+json payloads against the catalog
+
+Run "failbook <command> -h" for options of a command.
+`
+
+func runCommand(cfg *config.Config, name string, args []string) int {
+	switch name {
+	case "openapi":
+		return runOpenAPI(cfg, args)
+	case "import":
+		return runImport(cfg, args)
+	case "export":
+		return runExport(cfg, args)
+	case "lint":
+		return runLint(cfg, args)
+	case "validate":
+		return runValidate(cfg, args)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(os.Stdout, usage)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n%s", name, usage)
+		return 2
+	}
+}
+
+// loadOptions returns options for loading problem configurations with the
+// given unknown fields mode. Unrecognized modes fall back to warnings.
+func loadOptions(unknownFields string) problems.LoadOptions {
+	mode := problems.UnknownFieldsMode(unknownFields)
+	switch mode {
+	case problems.UnknownFieldsIgnore, problems.UnknownFieldsWarn, problems.UnknownFieldsError:
+	default:
+		log.Warn().Str("value", unknownFields).Msg("unrecognized unknown fields mode, using \"warn\"")
+		mode = problems.UnknownFieldsWarn
+	}
+	return problems.LoadOptions{UnknownFields: mode}
+}
+
+// writeOutput writes data to the file at path, or to stdout if path is empty
+// or "-".
+func writeOutput(path string, data []byte) error {
+	if path == "" || path == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// parseFlags parses flags that may be interleaved with positional arguments,
+// and returns the positional arguments.
+func parseFlags(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
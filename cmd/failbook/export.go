@@ -0,0 +1,171 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+type exportPage struct {
+	path     string
+	template string
+	data     any
+}
+
+// runExport renders the documentation as a static site. Problem pages are
+// written as <id>/index.html, so that IDs with multiple path segments become
+// nested directories and links work the same way as when served by Failbook.
+func runExport(cfg *config.Config, args []string) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	dir := flags.String("dir", cfg.ProblemsDir, "directory containing problem YAML files")
+	out := flags.String("out", "", "directory to write the static site to")
+	baseHref := flags.String("base-href", cfg.BaseHref, "base path the site is hosted under")
+	clean := flags.Bool("clean", false, "remove the output directory before exporting")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *out == "" {
+		fmt.Fprintln(os.Stderr, "usage: failbook export -out <dir>")
+		return 2
+	}
+	if *clean {
+		if err := checkCleanable(*out, *dir); err != nil {
+			log.Error().Err(err).Str("out", *out).Msg("refusing to clean output directory")
+			return 1
+		}
+	}
+
+	exportCfg := *cfg
+	exportCfg.BaseHref = *baseHref
+
+	problemRegistry, err := problems.LoadFromDirectoryWithOptions(*dir, loadOptions(cfg.UnknownFields))
+	if err != nil {
+		log.Error().Err(err).Msg("failed to load error configurations")
+		return 1
+	}
+
+	templates, err := template.New("").Funcs(templateFuncs()).ParseGlob(templatesPattern)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to parse templates")
+		return 1
+	}
+
+	if *clean {
+		if err := os.RemoveAll(*out); err != nil {
+			log.Error().Err(err).Str("out", *out).Msg("failed to clean output directory")
+			return 1
+		}
+	}
+
+	opts := indexOptions{view: indexView(cfg.IndexView)}
+	pages := []exportPage{
+		{path: "index.html", template: "index.tmpl", data: indexPageData(problemRegistry, &exportCfg, opts)},
+		{path: "404.html", template: "404.tmpl", data: notFoundPageData(&exportCfg)},
+	}
+	for _, tag := range problemRegistry.Tags() {
+		pages = append(pages, exportPage{
+			path:     filepath.Join("_tags", tag, "index.html"),
+			template: "index.tmpl",
+			data:     tagPageData(problemRegistry, &exportCfg, tag, opts),
+		})
+	}
+	for _, prefix := range sections(problemRegistry) {
+		pages = append(pages, exportPage{
+			path:     filepath.Join(filepath.FromSlash(prefix), "index.html"),
+			template: "index.tmpl",
+			data:     sectionPageData(problemRegistry, &exportCfg, prefix, opts),
+		})
+	}
+	for p := range problemRegistry.Sorted() {
+		if !filepath.IsLocal(filepath.FromSlash(p.ID)) {
+			log.Error().Str("id", p.ID).Msg("problem ID cannot be mapped to a file within output directory")
+			return 1
+		}
+		data := problemPageData(problemRegistry, p, &exportCfg)
+		// Exported pages ignore query parameters, so categories link nowhere.
+		delete(data, "categoryURL")
+		pages = append(pages, exportPage{
+			path:     filepath.Join(filepath.FromSlash(p.ID), "index.html"),
+			template: "problem.tmpl",
+			data:     data,
+		})
+	}
+
+	for _, page := range pages {
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, page.template, page.data); err != nil {
+			log.Error().Err(err).Str("file", page.path).Msg("failed to render page")
+			return 1
+		}
+
+		path := filepath.Join(*out, page.path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			log.Error().Err(err).Str("file", path).Msg("failed to create directory")
+			return 1
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			log.Error().Err(err).Str("file", path).Msg("failed to write page")
+			return 1
+		}
+	}
+
+	log.Info().Int("pages", len(pages)).Str("out", *out).Msg("exported static site")
+	return 0
+}
+
+// checkCleanable reports whether out is safe to remove before exporting,
+// which rules out filesystem roots, the working directory and its parents,
+// and directories containing the problems.
+func checkCleanable(out string, problemsDir string) error {
+	path := resolvePath(out)
+	if filepath.Dir(path) == path {
+		return fmt.Errorf("%s is a filesystem root", out)
+	}
+	if wd, err := os.Getwd(); err == nil && containsPath(path, resolvePath(wd)) {
+		return fmt.Errorf("%s contains the working directory", out)
+	}
+	if containsPath(path, resolvePath(problemsDir)) {
+		return fmt.Errorf("%s contains the problems directory %s", out, problemsDir)
+	}
+	return nil
+}
+
+// resolvePath returns the absolute path with symlinks resolved, as far as
+// the path exists.
+func resolvePath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return path
+}
+
+// containsPath reports whether path is parent or one of its descendants.
+func containsPath(parent string, path string) bool {
+	rel, err := filepath.Rel(parent, path)
+	return err == nil && (rel == "." || filepath.IsLocal(rel))
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/malczuuu/failbook/internal/config"
+)
+
+func TestRunExport(t *testing.T) {
+	problemsDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(problemsDir, "problems.yaml"), []byte(testProblems), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	out := filepath.Join(t.TempDir(), "site")
+	if err := os.MkdirAll(out, 0755); err != nil {
+		t.Fatalf("failed to create output directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(out, "stale.html"), nil, 0644); err != nil {
+		t.Fatalf("failed to create stale file: %v", err)
+	}
+
+	// Templates are read relative to the working directory.
+	t.Chdir("../..")
+
+	cfg := &config.Config{BaseHref: "/", IndexView: listView, UnknownFields: "warn"}
+	if code := runExport(cfg, []string{"-dir", problemsDir, "-out", out, "-clean"}); code != 0 {
+		t.Fatalf("expected exit code 0 but got %d", code)
+	}
+
+	for _, page := range []string{
+		"index.html",
+		"404.html",
+		"404/index.html",
+		"validation/constraint-violation/index.html",
+		"validation/index.html",
+		"_tags/client/index.html",
+		"_tags/validation/index.html",
+	} {
+		if _, err := os.Stat(filepath.Join(out, filepath.FromSlash(page))); err != nil {
+			t.Errorf("expected page %s to be exported: %v", page, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(out, "stale.html")); !os.IsNotExist(err) {
+		t.Errorf("expected stale file to be cleaned")
+	}
+
+	page, err := os.ReadFile(filepath.Join(out, "validation", "constraint-violation", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read exported page: %v", err)
+	}
+	if strings.Contains(string(page), "?category=") {
+		t.Errorf("expected exported page not to link to the category filter")
+	}
+}
+
+func TestRunExport_RefusesToClean(t *testing.T) {
+	root := t.TempDir()
+	problemsDir := filepath.Join(root, "problems")
+	if err := os.MkdirAll(problemsDir, 0755); err != nil {
+		t.Fatalf("failed to create problems directory: %v", err)
+	}
+	problemFile := filepath.Join(problemsDir, "problems.yaml")
+	if err := os.WriteFile(problemFile, []byte(testProblems), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	t.Chdir(root)
+
+	for _, out := range []string{".", root, problemsDir, filepath.Join(problemsDir, "..")} {
+		if code := runExport(&config.Config{}, []string{"-dir", problemsDir, "-out", out, "-clean"}); code != 1 {
+			t.Errorf("expected cleaning %s to be refused, got exit code %d", out, code)
+		}
+	}
+	if _, err := os.Stat(problemFile); err != nil {
+		t.Fatalf("expected problems to be left intact: %v", err)
+	}
+}
+
+func TestCheckCleanable(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	problemsDir := t.TempDir()
+
+	tests := []struct {
+		name string
+		out  string
+		safe bool
+	}{
+		{"filesystem root", string(filepath.Separator), false},
+		{"working directory", ".", false},
+		{"parent of working directory", filepath.Dir(wd), false},
+		{"problems directory", problemsDir, false},
+		{"parent of problems directory", filepath.Dir(problemsDir), false},
+		{"output directory", filepath.Join(t.TempDir(), "site"), true},
+		{"subdirectory of working directory", "site", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCleanable(tt.out, problemsDir)
+			if tt.safe && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tt.safe && err == nil {
+				t.Errorf("expected %s not to be cleanable", tt.out)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/goccy/go-yaml"
+	"github.com/rs/zerolog/log"
+
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/openapi"
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+// problemStub is the YAML layout of generated problem files, omitting fields
+// that are left for the author to fill in.
+type problemStub struct {
+	Version     string `yaml:"version"`
+	ID          string `yaml:"id"`
+	Title       string `yaml:"title"`
+	StatusCode  int    `yaml:"status_code"`
+	Summary     string `yaml:"summary,omitempty"`
+	Description string `yaml:"description,omitempty"`
+}
+
+func runImport(cfg *config.Config, args []string) int {
+	if len(args) == 0 || args[0] != "openapi" {
+		fmt.Fprintln(os.Stderr, "usage: failbook import openapi <spec> -out <dir>")
+		return 2
+	}
+
+	flags := flag.NewFlagSet("import openapi", flag.ContinueOnError)
+	dir := flags.String("dir", cfg.ProblemsDir, "directory containing problem YAML files, checked for existing IDs")
+	out := flags.String("out", "", "directory to write problem YAML files to")
+	typePrefix := flags.String("type-prefix", cfg.PublicURL, "prefix stripped from type URIs to derive problem IDs")
+	positional, err := parseFlags(flags, args[1:])
+	if err != nil {
+		return 2
+	}
+	if len(positional) != 1 || *out == "" {
+		fmt.Fprintln(os.Stderr, "usage: failbook import openapi <spec> -out <dir>")
+		return 2
+	}
+
+	data, err := os.ReadFile(positional[0])
+	if err != nil {
+		log.Error().Err(err).Msg("failed to read OpenAPI document")
+		return 1
+	}
+
+	stubs, err := openapi.ExtractProblems(data, *typePrefix)
+	if err != nil {
+		log.Error().Err(err).Str("spec", positional[0]).Msg("failed to import OpenAPI document")
+		return 1
+	}
+
+	existing := map[string]bool{}
+	for _, d := range []string{*dir, *out} {
+		if _, err := os.Stat(d); os.IsNotExist(err) {
+			continue
+		}
+		problemRegistry, err := problems.LoadFromDirectoryWithOptions(d, loadOptions(cfg.UnknownFields))
+		if err != nil {
+			log.Error().Err(err).Str("dir", d).Msg("failed to load existing problem configurations")
+			return 1
+		}
+		for id := range problemRegistry.All() {
+			existing[id] = true
+		}
+	}
+
+	written := 0
+	for _, stub := range stubs {
+		if existing[stub.ID] {
+			log.Info().Str("id", stub.ID).Msg("skipping problem that already exists")
+			continue
+		}
+
+		if err := problems.ValidateProblemConfig(stub); err != nil {
+			log.Warn().Err(err).Str("id", stub.ID).Msg("skipping invalid problem")
+			continue
+		}
+
+		if !filepath.IsLocal(filepath.FromSlash(stub.ID)) {
+			log.Warn().Str("id", stub.ID).Msg("skipping problem, ID cannot be mapped to a file within output directory")
+			continue
+		}
+
+		path := filepath.Join(*out, filepath.FromSlash(stub.ID)+".yaml")
+		if _, err := os.Stat(path); err == nil {
+			log.Warn().Str("id", stub.ID).Str("file", path).Msg("skipping problem, file already exists")
+			continue
+		}
+
+		data, err := yaml.Marshal(problemStub{
+			Version:    stub.Version,
+			ID:         stub.ID,
+			Title:      stub.Title,
+			StatusCode: stub.StatusCode,
+			Summary:    stub.Summary,
+		})
+		if err != nil {
+			log.Error().Err(err).Str("id", stub.ID).Msg("failed to encode problem")
+			return 1
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			log.Error().Err(err).Str("file", path).Msg("failed to create directory")
+			return 1
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			log.Error().Err(err).Str("file", path).Msg("failed to write problem")
+			return 1
+		}
+
+		log.Info().Str("id", stub.ID).Str("file", path).Msg("imported problem")
+		written++
+	}
+
+	log.Info().Int("found", len(stubs)).Int("written", written).Msg("import completed")
+	return 0
+}
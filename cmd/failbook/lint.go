@@ -0,0 +1,76 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/problems"
+	"github.com/malczuuu/failbook/internal/report"
+)
+
+// runLint exits with 1 if any diagnostic reaches the -fail-on severity.
+func runLint(cfg *config.Config, args []string) int {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	format := flags.String("format", "text", "output format, one of: "+strings.Join(report.Formats, ", "))
+	out := flags.String("out", "-", "output file, \"-\" for stdout")
+	failOn := flags.String("fail-on", "error", "lowest severity failing the command, \"error\" or \"warning\"")
+	unknownFields := flags.String("unknown-fields", cfg.UnknownFields, "how to report unknown fields, one of: ignore, warn, error")
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) > 1 || (*failOn != "error" && *failOn != "warning") {
+		fmt.Fprintln(os.Stderr, "usage: failbook lint [dir] [-format text|json|sarif|junit] [-fail-on error|warning] [-unknown-fields ignore|warn|error]")
+		return 2
+	}
+
+	dir := cfg.ProblemsDir
+	if len(positional) == 1 {
+		dir = positional[0]
+	}
+
+	result, err := problems.Lint(dir, loadOptions(*unknownFields))
+	if err != nil {
+		log.Error().Err(err).Str("dir", dir).Msg("failed to lint problem configurations")
+		return 1
+	}
+
+	var buf bytes.Buffer
+	if err := report.Write(&buf, *format, cfg.Version, result); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if err := writeOutput(*out, buf.Bytes()); err != nil {
+		log.Error().Err(err).Str("out", *out).Msg("failed to write lint report")
+		return 1
+	}
+
+	failing := result.Count(problems.SeverityError)
+	if *failOn == "warning" {
+		failing += result.Count(problems.SeverityWarning)
+	}
+	if failing > 0 {
+		return 1
+	}
+	return 0
+}
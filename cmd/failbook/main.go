@@ -17,13 +17,16 @@ package main
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
-	"html/template"
 	"io"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -32,28 +35,59 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog/log"
 
+	"github.com/malczuuu/failbook/internal/api"
 	"github.com/malczuuu/failbook/internal/config"
 	"github.com/malczuuu/failbook/internal/health"
+	"github.com/malczuuu/failbook/internal/httpcache"
+	"github.com/malczuuu/failbook/internal/jsonschema"
 	"github.com/malczuuu/failbook/internal/logging"
-	"github.com/malczuuu/failbook/internal/markdown"
 	"github.com/malczuuu/failbook/internal/metrics"
 	"github.com/malczuuu/failbook/internal/middleware"
+	"github.com/malczuuu/failbook/internal/mock"
+	"github.com/malczuuu/failbook/internal/negotiation"
+	"github.com/malczuuu/failbook/internal/openapi"
 	"github.com/malczuuu/failbook/internal/problems"
 )
 
-var launchTimestamp = time.Now().Unix()
+// templateVersion is a digest of the HTML templates, so that cached pages are
+// invalidated when templates change between releases.
+var templateVersion string
+
+// startedAt is when the templates and configuration were loaded. Pages are
+// dated no earlier, so that caches revalidating with If-Modified-Since alone
+// notice changes of either, like the ETag does.
+var startedAt time.Time
 
 func main() {
 	cfg := config.Load()
+
+	if len(os.Args) > 1 && os.Args[1] != "serve" {
+		logging.ConfigureCommandLogger(&cfg)
+		os.Exit(runCommand(&cfg, os.Args[1], os.Args[2:]))
+	}
+
 	logging.ConfigureLogger(&cfg)
+	serve(cfg)
+}
 
+func serve(cfg config.Config) {
 	log.Info().Str("version", cfg.Version).Msg("starting failbook application")
 
-	problemRegistry, err := problems.LoadFromDirectory(cfg.ProblemsDir)
+	cfg.IndexView = indexView(cfg.IndexView)
+	cfg.AncestorFallback = ancestorFallback(cfg.AncestorFallback)
+
+	problemStore, err := problems.NewStore(cfg.ProblemsDir, loadOptions(cfg.UnknownFields))
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to load error configurations")
 	}
 
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+
+	if cfg.WatchEnabled {
+		go problems.NewWatcher(problemStore, cfg.WatchInterval, cfg.WatchDebounce).Run(watchCtx)
+	}
+
 	metrics.Init()
 
 	healthStatus := health.NewStatus()
@@ -64,8 +98,14 @@ func main() {
 	router.Use(middleware.ZerologRecovery())
 	router.Use(middleware.LoggingAndMetricsMiddleware())
 
-	router.SetFuncMap(template.FuncMap{"trimSuffix": trimSuffix})
-	router.LoadHTMLGlob("./templates/*")
+	router.SetFuncMap(templateFuncs())
+	router.LoadHTMLGlob(templatesPattern)
+
+	templateVersion, err = computeTemplateVersion(templatesPattern)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to read templates")
+	}
+	startedAt = time.Now()
 
 	if cfg.HealthEnabled {
 		router.GET("/manage/health/live", health.LivenessHandler())
@@ -80,31 +120,73 @@ func main() {
 		log.Info().Str("path", "/manage/prometheus").Msg("prometheus endpoint exposed")
 	}
 
+	if cfg.ReloadEnabled {
+		router.POST("/manage/reload", func(c *gin.Context) {
+			report, err := problemStore.Reload()
+			if err != nil {
+				c.JSON(http.StatusUnprocessableEntity, report)
+				return
+			}
+			c.JSON(http.StatusOK, report)
+		})
+		log.Info().Str("path", "/manage/reload").Msg("reload endpoint exposed")
+	}
+
 	router.GET("/manage/info", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"version": cfg.Version})
+		problemRegistry := problemStore.Registry()
+		c.JSON(http.StatusOK, gin.H{
+			"version": cfg.Version,
+			"catalog": gin.H{
+				"generation": problemRegistry.Generation(),
+				"loaded_at":  problemRegistry.LoadedAt(),
+				"problems":   problemRegistry.Len(),
+			},
+		})
+	})
+
+	router.GET("/resolve", api.ResolveHandler(problemStore, &cfg))
+	router.GET("/api/problems", api.ListProblemsHandler(problemStore, &cfg))
+	router.GET("/api/problems/*id", api.GetProblemHandler(problemStore, &cfg))
+	router.POST("/api/validate", api.ValidateHandler(problemStore, &cfg))
+	router.GET("/api/openapi.json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, openapi.Generate(problemStore.Registry(), &cfg))
+	})
+	router.GET("/api/schema.json", func(c *gin.Context) {
+		renderJSON(c, http.StatusOK, jsonschema.MediaType, jsonschema.GenerateBundle(problemStore.Registry(), &cfg))
 	})
 
+	if cfg.MockEnabled {
+		mockHandler := mock.Handler(problemStore, &cfg)
+		router.GET("/_mock/*id", mockHandler)
+		router.POST("/_mock/*id", mockHandler)
+		log.Info().Str("path", "/_mock/").Msg("mock endpoints exposed")
+	}
+
 	router.GET("/", func(c *gin.Context) {
-		renderIndex(c, problemRegistry, &cfg)
+		renderIndex(c, problemStore.Registry(), &cfg)
+	})
+
+	router.GET("/_tags/:tag", func(c *gin.Context) {
+		renderTag(c, problemStore.Registry(), c.Param("tag"), &cfg)
 	})
 
 	router.GET("/:id", func(c *gin.Context) {
 		id := c.Param("id")
-		renderProblem(c, problemRegistry, id, &cfg)
+		renderProblem(c, problemStore.Registry(), id, &cfg)
 	})
 
 	// Walkaround for resolving any HTTP path into a problem documentation page.
 	router.GET("/:id/*wildcard", func(c *gin.Context) {
 		id := c.Param("id") + c.Param("wildcard")
-		renderProblem(c, problemRegistry, id, &cfg)
+		renderProblem(c, problemStore.Registry(), id, &cfg)
 	})
 
 	router.NoRoute(func(c *gin.Context) {
-		c.HTML(http.StatusNotFound, "404.tmpl", gin.H{"baseHref": cfg.BaseHref})
+		renderNotFound(c, problemStore.Registry(), &cfg)
 	})
 
 	router.NoMethod(func(c *gin.Context) {
-		c.HTML(http.StatusNotFound, "404.tmpl", gin.H{"baseHref": cfg.BaseHref})
+		renderNotFound(c, problemStore.Registry(), &cfg)
 	})
 
 	addr := ":" + cfg.Port
@@ -120,12 +202,21 @@ func main() {
 	}()
 
 	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	sig := <-quit
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+	var sig os.Signal
+	for sig = range quit {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		log.Info().Str("signal", sig.String()).Msg("reloading problem configurations")
+		_, _ = problemStore.Reload()
+	}
 
 	log.Info().Str("signal", sig.String()).Msg("commencing graceful shutdown")
 
 	healthStatus.SetNotReady()
+	stopWatching()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -137,74 +228,366 @@ func main() {
 	log.Info().Msg("graceful shutdown completed")
 }
 
-func trimSuffix(text string, suffix string) string {
-	if strings.HasSuffix(text, suffix) {
-		return text[:len(text)-len(suffix)]
-	}
-	return text
-}
-
 func renderIndex(c *gin.Context, problemRegistry *problems.ProblemRegistry, cfg *config.Config) {
-	etag := computeIndexETag()
-	c.Header("ETag", etag)
+	opts, err := parseIndexOptions(c, cfg)
+	if err != nil {
+		api.AbortWithProblem(c, problemRegistry, cfg, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	if match := c.GetHeader("If-None-Match"); match == etag {
-		c.Status(http.StatusNotModified)
+	if httpcache.NotModified(c, httpcache.Validators{
+		ETag:         computeIndexETag(problemRegistry, c.Request.URL.RequestURI(), cfg),
+		LastModified: pageModTime(problemRegistry.ModTime()),
+		CacheControl: cfg.CacheControl,
+	}) {
 		return
 	}
 
-	problemsAsMap := problemRegistry.GetAll()
+	c.HTML(http.StatusOK, "index.tmpl", indexPageData(problemRegistry, cfg, opts))
+}
 
-	problemsAsList := make([]*problems.ProblemConfig, 0, len(problemsAsMap))
-	for _, p := range problemsAsMap {
-		problemsAsList = append(problemsAsList, p)
+// renderTag renders the index page narrowed down to problems with a tag,
+// which also accepts the filters of the index page.
+func renderTag(c *gin.Context, problemRegistry *problems.ProblemRegistry, tag string, cfg *config.Config) {
+	if !slices.Contains(problemRegistry.Tags(), tag) {
+		renderNotFound(c, problemRegistry, cfg)
+		return
 	}
 
-	sort.Slice(problemsAsList, func(i, j int) bool {
-		if problemsAsList[i].StatusCode != problemsAsList[j].StatusCode {
-			return problemsAsList[i].StatusCode < problemsAsList[j].StatusCode
-		}
-		return problemsAsList[i].Name < problemsAsList[j].Name
-	})
+	opts, err := parseIndexOptions(c, cfg)
+	if err != nil {
+		api.AbortWithProblem(c, problemRegistry, cfg, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	c.HTML(http.StatusOK, "index.tmpl", gin.H{
-		"title":    "API Error Documentation",
-		"problems": problemsAsList,
-		"baseHref": cfg.BaseHref,
-	})
+	if httpcache.NotModified(c, httpcache.Validators{
+		ETag:         computeIndexETag(problemRegistry, c.Request.URL.RequestURI(), cfg),
+		LastModified: pageModTime(problemRegistry.ModTime()),
+		CacheControl: cfg.CacheControl,
+	}) {
+		return
+	}
+
+	c.HTML(http.StatusOK, "index.tmpl", tagPageData(problemRegistry, cfg, tag, opts))
 }
 
+// problemMediaTypes lists representations of problem pages in order of
+// preference, so that clients accepting anything get the HTML page.
+var problemMediaTypes = []string{"text/html", "application/json", "text/markdown", "text/plain", jsonschema.MediaType}
+
 func renderProblem(c *gin.Context, problemRegistry *problems.ProblemRegistry, id string, cfg *config.Config) {
+	c.Header("Vary", "Accept")
+
+	mediaType := ""
 	problem, exists := problemRegistry.Get(id)
 	if !exists {
-		c.HTML(http.StatusNotFound, "404.tmpl", gin.H{"baseHref": cfg.BaseHref})
+		// The schema of a problem is also available at its page path with
+		// the .schema.json suffix, regardless of the Accept header.
+		if problemID, ok := strings.CutSuffix(id, jsonschema.Suffix); ok {
+			problem, exists = problemRegistry.Get(problemID)
+			mediaType = jsonschema.MediaType
+		}
+	}
+	if !exists {
+		resolveUnknownID(c, problemRegistry, id, cfg)
+		return
+	}
+
+	serveProblem(c, problemRegistry, problem, mediaType, "", cfg)
+}
+
+// serveProblem responds with a representation of a problem, negotiated
+// unless mediaType is given. A non-empty fallbackID is the undocumented ID
+// the problem is served for as its nearest ancestor.
+func serveProblem(c *gin.Context, problemRegistry *problems.ProblemRegistry, problem *problems.ProblemConfig, mediaType string, fallbackID string, cfg *config.Config) {
+	if mediaType == "" {
+		mediaType = negotiation.Negotiate(c.GetHeader("Accept"), problemMediaTypes...)
+	}
+	if mediaType == "" {
+		mediaType = problemMediaTypes[0]
+	}
+
+	api.SetDeprecationHeaders(c, problemRegistry, cfg, problem)
+
+	if httpcache.NotModified(c, httpcache.Validators{
+		ETag:         computeProblemETag(problemRegistry, problem, mediaType, fallbackID, cfg),
+		LastModified: pageModTime(problemModTime(problemRegistry, problem)),
+		CacheControl: cfg.CacheControl,
+	}) {
 		return
 	}
 
-	etag := computeProblemETag(problem)
-	c.Header("ETag", etag)
+	switch mediaType {
+	case "application/json":
+		c.JSON(http.StatusOK, api.NewProblem(problem, cfg))
+	case "text/markdown":
+		c.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(problem.Description))
+	case "text/plain":
+		c.String(http.StatusOK, formatProblemText(problem))
+	case jsonschema.MediaType:
+		renderJSON(c, http.StatusOK, jsonschema.MediaType, jsonschema.Generate(problem, cfg))
+	default:
+		data := problemPageData(problemRegistry, problem, cfg)
+		data["fallbackID"] = fallbackID
+		c.HTML(http.StatusOK, "problem.tmpl", data)
+	}
+}
 
-	if match := c.GetHeader("If-None-Match"); match == etag {
-		c.Status(http.StatusNotModified)
+// resolveUnknownID responds to a request for an ID that is no problem. It
+// redirects a former problem ID (or its schema) to the current one, and
+// otherwise renders the 410 page of removed problems, the landing page of ID
+// path prefixes, the nearest ancestor of nested IDs if enabled, or the 404
+// page for anything else.
+func resolveUnknownID(c *gin.Context, problemRegistry *problems.ProblemRegistry, id string, cfg *config.Config) {
+	alias, suffix := id, ""
+	if trimmed, ok := strings.CutSuffix(id, jsonschema.Suffix); ok {
+		if _, exists := problemRegistry.ResolveAlias(trimmed); exists {
+			alias, suffix = trimmed, jsonschema.Suffix
+		}
+	}
+	if problem, exists := problemRegistry.ResolveAlias(alias); exists {
+		api.RedirectPermanently(c, cfg, "/"+problem.ID+suffix)
 		return
 	}
 
-	c.HTML(http.StatusOK, "problem.tmpl", gin.H{
-		"problem":         problem,
-		"baseHref":        cfg.BaseHref,
-		"descriptionHTML": markdown.RenderToHTML(problem.Description),
-	})
+	if tombstone, exists := problemRegistry.Tombstone(id); exists {
+		renderGone(c, problemRegistry, tombstone, cfg)
+		return
+	}
+	if isSection(problemRegistry, strings.TrimSuffix(id, "/")) {
+		renderSection(c, problemRegistry, strings.TrimSuffix(id, "/"), cfg)
+		return
+	}
+	if cfg.AncestorFallback == fallbackNotice || cfg.AncestorFallback == fallbackRedirect {
+		if ancestor, exists := problemRegistry.NearestAncestor(id); exists {
+			renderFallback(c, problemRegistry, id, ancestor, cfg)
+			return
+		}
+	}
+	renderNotFound(c, problemRegistry, cfg)
+}
+
+// renderNotFound responds with the 404 page, or with problem details for
+// clients preferring JSON, such as the ones following a broken type URI.
+func renderNotFound(c *gin.Context, problemRegistry *problems.ProblemRegistry, cfg *config.Config) {
+	c.Header("Vary", "Accept")
+
+	mediaType := negotiation.Negotiate(c.GetHeader("Accept"), "text/html", api.ProblemMediaType, "application/json")
+	if mediaType == api.ProblemMediaType || mediaType == "application/json" {
+		api.AbortWithProblem(c, problemRegistry, cfg, http.StatusNotFound,
+			fmt.Sprintf("no problem documentation found at %s", c.Request.URL.Path))
+		return
+	}
+
+	c.HTML(http.StatusNotFound, "404.tmpl", notFoundPageData(cfg))
+}
+
+// Modes of the nearest-ancestor fallback for unknown nested IDs, which is
+// off unless configured.
+const (
+	fallbackOff      = "off"
+	fallbackNotice   = "notice"
+	fallbackRedirect = "redirect"
+)
+
+// ancestorFallback returns the configured fallback mode. Unrecognized modes
+// turn the fallback off with a warning.
+func ancestorFallback(mode string) string {
+	switch mode {
+	case fallbackOff, fallbackNotice, fallbackRedirect:
+		return mode
+	default:
+		log.Warn().Str("value", mode).Msg("unrecognized ancestor fallback mode, using \"off\"")
+		return fallbackOff
+	}
+}
+
+// renderFallback serves the nearest documented ancestor of an unknown nested
+// ID, either with a notice that the requested type is undocumented, or as a
+// 302 Found redirect to the ancestor, which is temporary since the type may
+// get documented later.
+func renderFallback(c *gin.Context, problemRegistry *problems.ProblemRegistry, id string, ancestor *problems.ProblemConfig, cfg *config.Config) {
+	metrics.ProblemFallbacksTotal.WithLabelValues(ancestor.ID, cfg.AncestorFallback).Inc()
+	log.Debug().Str("id", id).Str("ancestor", ancestor.ID).Str("mode", cfg.AncestorFallback).Msg("serving nearest ancestor of unknown problem")
+
+	suffix, mediaType := "", ""
+	if strings.HasSuffix(id, jsonschema.Suffix) {
+		suffix, mediaType = jsonschema.Suffix, jsonschema.MediaType
+	}
+
+	if cfg.AncestorFallback == fallbackRedirect {
+		c.Redirect(http.StatusFound, pageHref(cfg, ancestor.ID+suffix))
+		return
+	}
+
+	c.Header("Vary", "Accept")
+	c.Header("Content-Location", pageHref(cfg, ancestor.ID+suffix))
+	serveProblem(c, problemRegistry, ancestor, mediaType, strings.TrimSuffix(id, suffix), cfg)
+}
+
+// renderSection renders the landing page of an ID path prefix. Clients
+// preferring JSON get the 404 problem details, as the prefix is no type URI.
+func renderSection(c *gin.Context, problemRegistry *problems.ProblemRegistry, prefix string, cfg *config.Config) {
+	if negotiation.Negotiate(c.GetHeader("Accept"), "text/html", "application/json") == "application/json" {
+		renderNotFound(c, problemRegistry, cfg)
+		return
+	}
+
+	opts, err := parseIndexOptions(c, cfg)
+	if err != nil {
+		api.AbortWithProblem(c, problemRegistry, cfg, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.Header("Vary", "Accept")
+	if httpcache.NotModified(c, httpcache.Validators{
+		ETag:         computeIndexETag(problemRegistry, c.Request.URL.RequestURI(), cfg),
+		LastModified: pageModTime(problemRegistry.ModTime()),
+		CacheControl: cfg.CacheControl,
+	}) {
+		return
+	}
+
+	c.HTML(http.StatusOK, "index.tmpl", sectionPageData(problemRegistry, cfg, prefix, opts))
+}
+
+// renderGone responds with the 410 page of a removed problem, or with its
+// tombstone for clients preferring JSON.
+func renderGone(c *gin.Context, problemRegistry *problems.ProblemRegistry, tombstone *problems.Tombstone, cfg *config.Config) {
+	c.Header("Vary", "Accept")
+
+	mediaType := negotiation.Negotiate(c.GetHeader("Accept"), "text/html", "application/json")
+	if mediaType == "application/json" {
+		api.AbortWithGone(c, problemRegistry, cfg, tombstone)
+		return
+	}
+
+	if replacement, exists := problemRegistry.Get(tombstone.ReplacedBy); exists {
+		api.SetSuccessorLink(c, api.TypeURI(replacement, cfg))
+	}
+	c.HTML(http.StatusGone, "410.tmpl", gonePageData(problemRegistry, tombstone, cfg))
+}
+
+// renderJSON responds with a JSON body of a media type other than
+// application/json.
+func renderJSON(c *gin.Context, status int, mediaType string, body any) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	c.Data(status, mediaType, data)
+}
+
+// formatProblemText renders a problem for reading in a terminal, keeping the
+// Markdown description as is.
+func formatProblemText(p *problems.ProblemConfig) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "[%d] %s\n", p.StatusCode, p.Name)
+	if p.Name != p.Title {
+		fmt.Fprintf(&b, "%s\n", p.Title)
+	}
+	if p.Category != "" {
+		fmt.Fprintf(&b, "Category: %s\n", p.Category)
+	}
+	if len(p.Tags) > 0 {
+		fmt.Fprintf(&b, "Tags: %s\n", strings.Join(p.Tags, ", "))
+	}
+	if p.Summary != "" {
+		fmt.Fprintf(&b, "\n%s\n", p.Summary)
+	}
+	if description := strings.TrimSpace(p.Description); description != "" {
+		fmt.Fprintf(&b, "\n%s\n", description)
+	}
+	if len(p.Extensions) > 0 {
+		b.WriteString("\nExtension Members:\n")
+		for _, ext := range p.Extensions {
+			required := "optional"
+			if ext.Required {
+				required = "required"
+			}
+			fmt.Fprintf(&b, "  - %s (%s, %s)", ext.Name, ext.Type, required)
+			if ext.Description != "" {
+				fmt.Fprintf(&b, ": %s", ext.Description)
+			}
+			b.WriteString("\n")
+		}
+	}
+	if len(p.Links) > 0 {
+		b.WriteString("\nAdditional Resources:\n")
+		for _, link := range p.Links {
+			fmt.Fprintf(&b, "  - %s: %s\n", link.Title, link.Href)
+		}
+	}
+	return b.String()
+}
+
+func computeTemplateVersion(pattern string) (string, error) {
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		io.WriteString(h, filepath.Base(path)+"\x00")
+		h.Write(content)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
 }
 
-func computeIndexETag() string {
+// computeIndexETag hashes everything the index page depends on, including
+// the request URI, which selects the listed problems.
+func computeIndexETag(problemRegistry *problems.ProblemRegistry, requestURI string, cfg *config.Config) string {
 	h := sha256.New()
-	io.WriteString(h, fmt.Sprintf("%d", launchTimestamp))
+	io.WriteString(h, templateVersion)
+	io.WriteString(h, requestURI)
+	io.WriteString(h, cfg.BaseHref)
+	io.WriteString(h, cfg.PublicURL)
+	io.WriteString(h, strconv.FormatBool(cfg.HideDeprecated))
+	io.WriteString(h, cfg.IndexView)
+	io.WriteString(h, problemRegistry.Digest())
 	return fmt.Sprintf(`"%x"`, h.Sum(nil))
 }
 
-func computeProblemETag(p *problems.ProblemConfig) string {
+func computeProblemETag(problemRegistry *problems.ProblemRegistry, p *problems.ProblemConfig, mediaType string, fallbackID string, cfg *config.Config) string {
 	h := sha256.New()
-	io.WriteString(h, fmt.Sprintf("%d", launchTimestamp))
-	io.WriteString(h, p.ID)
+	io.WriteString(h, templateVersion)
+	io.WriteString(h, cfg.BaseHref)
+	io.WriteString(h, cfg.PublicURL)
+	io.WriteString(h, mediaType)
+	io.WriteString(h, fallbackID)
+	io.WriteString(h, problemRegistry.ProblemDigest(p.ID))
+	if p.ReplacedBy != "" {
+		// The deprecation banner shows the replacement's status and name.
+		io.WriteString(h, problemRegistry.ProblemDigest(p.ReplacedBy))
+	}
 	return fmt.Sprintf(`"%x"`, h.Sum(nil))
 }
+
+// pageModTime returns the Last-Modified time of a page with content modified
+// at modTime, which is no earlier than startedAt.
+func pageModTime(modTime time.Time) time.Time {
+	if startedAt.After(modTime) {
+		return startedAt
+	}
+	return modTime
+}
+
+// problemModTime is the later of the modification times of a problem and its
+// replacement, which its page links to.
+func problemModTime(problemRegistry *problems.ProblemRegistry, p *problems.ProblemConfig) time.Time {
+	modTime := problemRegistry.ProblemModTime(p.ID)
+	if p.ReplacedBy != "" {
+		if replaced := problemRegistry.ProblemModTime(p.ReplacedBy); replaced.After(modTime) {
+			modTime = replaced
+		}
+	}
+	return modTime
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/metrics"
+)
+
+func TestRenderFallback(t *testing.T) {
+	tests := []struct {
+		name            string
+		mode            string
+		path            string
+		accept          string
+		status          int
+		contentType     string
+		location        string
+		contentLocation string
+		contains        string
+		ancestor        string
+	}{
+		{
+			name:            "notice page",
+			mode:            fallbackNotice,
+			path:            "/validation/constraint-violation/email",
+			accept:          "text/html",
+			status:          http.StatusOK,
+			contentType:     "text/html",
+			contentLocation: "/docs/validation/constraint-violation",
+			contains:        "The problem type <code>validation/constraint-violation/email</code> is not documented.",
+			ancestor:        "validation/constraint-violation",
+		},
+		{
+			name:            "notice json",
+			mode:            fallbackNotice,
+			path:            "/validation/constraint-violation/email",
+			accept:          "application/json",
+			status:          http.StatusOK,
+			contentType:     "application/json",
+			contentLocation: "/docs/validation/constraint-violation",
+			contains:        `"id":"validation/constraint-violation"`,
+			ancestor:        "validation/constraint-violation",
+		},
+		{
+			name:            "notice schema",
+			mode:            fallbackNotice,
+			path:            "/validation/constraint-violation/email.schema.json",
+			accept:          "text/html",
+			status:          http.StatusOK,
+			contentType:     "application/schema+json",
+			contentLocation: "/docs/validation/constraint-violation.schema.json",
+			contains:        `"$id":"/docs/validation/constraint-violation.schema.json"`,
+			ancestor:        "validation/constraint-violation",
+		},
+		{
+			name:     "redirect",
+			mode:     fallbackRedirect,
+			path:     "/validation/constraint-violation/email",
+			accept:   "text/html",
+			status:   http.StatusFound,
+			location: "/docs/validation/constraint-violation",
+			ancestor: "validation/constraint-violation",
+		},
+		{
+			name:     "redirect schema",
+			mode:     fallbackRedirect,
+			path:     "/404/user.schema.json",
+			accept:   "application/json",
+			status:   http.StatusFound,
+			location: "/docs/404.schema.json",
+			ancestor: "404",
+		},
+		{
+			name:        "off",
+			mode:        fallbackOff,
+			path:        "/validation/constraint-violation/email",
+			accept:      "text/html",
+			status:      http.StatusNotFound,
+			contentType: "text/html",
+		},
+		{
+			name:        "no ancestor",
+			mode:        fallbackNotice,
+			path:        "/billing/card",
+			accept:      "application/json",
+			status:      http.StatusNotFound,
+			contentType: "application/problem+json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(t, &config.Config{BaseHref: "/docs/", AncestorFallback: tt.mode})
+
+			var before float64
+			if tt.ancestor != "" {
+				before = testutil.ToFloat64(metrics.ProblemFallbacksTotal.WithLabelValues(tt.ancestor, tt.mode))
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Fatalf("expected status %d but got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+			if !strings.HasPrefix(w.Header().Get("Content-Type"), tt.contentType) {
+				t.Errorf("expected content type %s but got %s", tt.contentType, w.Header().Get("Content-Type"))
+			}
+			if w.Header().Get("Location") != tt.location {
+				t.Errorf("expected Location %q but got %q", tt.location, w.Header().Get("Location"))
+			}
+			if w.Header().Get("Content-Location") != tt.contentLocation {
+				t.Errorf("expected Content-Location %q but got %q", tt.contentLocation, w.Header().Get("Content-Location"))
+			}
+			if !strings.Contains(w.Body.String(), tt.contains) {
+				t.Errorf("expected body to contain %q: %s", tt.contains, w.Body.String())
+			}
+
+			if tt.ancestor != "" {
+				after := testutil.ToFloat64(metrics.ProblemFallbacksTotal.WithLabelValues(tt.ancestor, tt.mode))
+				if after != before+1 {
+					t.Errorf("expected fallback to be counted once, got %v more", after-before)
+				}
+			}
+		})
+	}
+}
+
+func TestAncestorFallback(t *testing.T) {
+	for mode, expected := range map[string]string{
+		"off":      fallbackOff,
+		"notice":   fallbackNotice,
+		"redirect": fallbackRedirect,
+		"":         fallbackOff,
+		"Notice":   fallbackOff,
+	} {
+		if got := ancestorFallback(mode); got != expected {
+			t.Errorf("expected mode %q to be %q but got %q", mode, expected, got)
+		}
+	}
+}
+
+func TestServeProblem_LastModified(t *testing.T) {
+	router := newTestRouter(t, &config.Config{BaseHref: "/", IndexView: listView})
+
+	previous := startedAt
+	t.Cleanup(func() { startedAt = previous })
+	startedAt = time.Now().Add(time.Hour).Truncate(time.Second)
+
+	req := httptest.NewRequest(http.MethodGet, "/404", nil)
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("If-Modified-Since", time.Now().UTC().Format(http.TimeFormat))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected pages cached before the start to be served again, got status %d", w.Code)
+	}
+	if w.Header().Get("Last-Modified") != startedAt.UTC().Format(http.TimeFormat) {
+		t.Errorf("expected Last-Modified of the start but got %q", w.Header().Get("Last-Modified"))
+	}
+}
+
+func TestRenderGone(t *testing.T) {
+	router := newTestRouter(t, &config.Config{BaseHref: "/", IndexView: listView})
+
+	tests := []struct {
+		accept      string
+		contentType string
+		contains    string
+	}{
+		{"text/html", "text/html", "Missing resources are reported as not found."},
+		{"application/json", "application/json", `"replacement_type_uri":"/404"`},
+		{"*/*", "text/html", "The problem type <code>legacy-missing</code> was removed on 2025-03-01."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/legacy-missing", nil)
+			req.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusGone {
+				t.Fatalf("expected status 410 but got %d", w.Code)
+			}
+			if !strings.HasPrefix(w.Header().Get("Content-Type"), tt.contentType) {
+				t.Errorf("expected content type %s but got %s", tt.contentType, w.Header().Get("Content-Type"))
+			}
+			if w.Header().Get("Link") != `</404>; rel="successor-version"` {
+				t.Errorf("unexpected Link header: %q", w.Header().Get("Link"))
+			}
+			if w.Header().Get("Vary") != "Accept" {
+				t.Errorf("expected Vary: Accept but got %q", w.Header().Get("Vary"))
+			}
+			if !strings.Contains(w.Body.String(), tt.contains) {
+				t.Errorf("expected body to contain %q: %s", tt.contains, w.Body.String())
+			}
+		})
+	}
+}
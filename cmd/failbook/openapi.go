@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/goccy/go-yaml"
+	"github.com/rs/zerolog/log"
+
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/openapi"
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+func runOpenAPI(cfg *config.Config, args []string) int {
+	flags := flag.NewFlagSet("openapi", flag.ContinueOnError)
+	dir := flags.String("dir", cfg.ProblemsDir, "directory containing problem YAML files")
+	out := flags.String("out", "-", "output file, \"-\" for stdout")
+	format := flags.String("format", "json", "output format, \"json\" or \"yaml\"")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	problemRegistry, err := problems.LoadFromDirectoryWithOptions(*dir, loadOptions(cfg.UnknownFields))
+	if err != nil {
+		log.Error().Err(err).Msg("failed to load error configurations")
+		return 1
+	}
+
+	document := openapi.Generate(problemRegistry, cfg)
+
+	var data []byte
+	switch *format {
+	case "json":
+		data, err = json.MarshalIndent(document, "", "  ")
+		data = append(data, '\n')
+	case "yaml":
+		data, err = yaml.Marshal(document)
+	default:
+		fmt.Fprintf(os.Stderr, "unsupported format: %s\n", *format)
+		return 2
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("failed to encode OpenAPI document")
+		return 1
+	}
+
+	if err := writeOutput(*out, data); err != nil {
+		log.Error().Err(err).Str("out", *out).Msg("failed to write OpenAPI document")
+		return 1
+	}
+	return 0
+}
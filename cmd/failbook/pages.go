@@ -0,0 +1,368 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"iter"
+	"maps"
+	"net/url"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"github.com/malczuuu/failbook/internal/api"
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/markdown"
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+const templatesPattern = "./templates/*"
+
+// templateFuncs returns functions available in HTML templates, both when
+// serving pages and when exporting a static site.
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{"trimSuffix": trimSuffix, "toJSON": toJSON, "inc": inc}
+}
+
+func trimSuffix(text string, suffix string) string {
+	if strings.HasSuffix(text, suffix) {
+		return text[:len(text)-len(suffix)]
+	}
+	return text
+}
+
+// toJSON formats a value decoded from YAML, like an extension example, the
+// way it would appear in a JSON response.
+func toJSON(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func inc(n int) int {
+	return n + 1
+}
+
+// Views of the index page, a flat list grouped by category or a tree of ID
+// path segments.
+const (
+	listView = "list"
+	treeView = "tree"
+)
+
+// indexOptions select what the index page (and the tag and section pages
+// built upon it) lists, and how.
+type indexOptions struct {
+	filter api.Filter
+	view   string
+	prefix string
+	query  url.Values
+}
+
+// parseIndexOptions reads the filters and the view query parameter, which
+// defaults to the configured index view.
+func parseIndexOptions(c *gin.Context, cfg *config.Config) (indexOptions, error) {
+	filter, err := api.ParseFilter(c)
+	if err != nil {
+		return indexOptions{}, err
+	}
+
+	view := c.DefaultQuery("view", cfg.IndexView)
+	if view != listView && view != treeView {
+		return indexOptions{}, fmt.Errorf("invalid view: %s", view)
+	}
+
+	return indexOptions{filter: filter, view: view, query: c.Request.URL.Query()}, nil
+}
+
+// indexView returns the configured index view. Unrecognized views fall back
+// to the list with a warning.
+func indexView(view string) string {
+	switch view {
+	case listView, treeView:
+		return view
+	default:
+		log.Warn().Str("value", view).Msg("unrecognized index view, using \"list\"")
+		return listView
+	}
+}
+
+// viewURL returns the relative URL of the current page in another view,
+// keeping the other query parameters.
+func (o indexOptions) viewURL(view string) string {
+	query := url.Values{}
+	maps.Copy(query, o.query)
+	query.Set("view", view)
+	return "?" + query.Encode()
+}
+
+// indexPageData returns the template data of the index page, listing the
+// problems matching the options grouped by category, or as a tree.
+func indexPageData(problemRegistry *problems.ProblemRegistry, cfg *config.Config, opts indexOptions) gin.H {
+	listed := listedProblems(problemRegistry, cfg, opts)
+	groups, count := groupByCategory(listed)
+
+	data := gin.H{
+		"title":        "API Error Documentation",
+		"groups":       groups,
+		"count":        count,
+		"filtered":     opts.filter.Active(),
+		"tags":         problemRegistry.Tags(),
+		"view":         opts.view,
+		"baseHref":     cfg.BaseHref,
+		"canonicalURL": canonicalURL(cfg, ""),
+	}
+	// Exported pages ignore query parameters, so they offer no other view.
+	if opts.query != nil {
+		data["listViewURL"] = opts.viewURL(listView)
+		data["treeViewURL"] = opts.viewURL(treeView)
+	}
+	if opts.view == treeView {
+		data["tree"] = problemTree(listed, opts.prefix, cfg)
+	}
+	return data
+}
+
+// tagPageData returns the template data of the page listing problems with a
+// tag, which is the index page narrowed down to the tag.
+func tagPageData(problemRegistry *problems.ProblemRegistry, cfg *config.Config, tag string, opts indexOptions) gin.H {
+	opts.filter = opts.filter.WithTag(tag)
+	data := indexPageData(problemRegistry, cfg, opts)
+	data["tag"] = tag
+	data["canonicalURL"] = canonicalURL(cfg, "_tags/"+tag)
+	return data
+}
+
+// sectionPageData returns the template data of the landing page of an ID
+// path prefix, like "validation" for "validation/constraint-violation",
+// which is the index page narrowed down to problems under the prefix and
+// shown as a tree unless requested otherwise.
+func sectionPageData(problemRegistry *problems.ProblemRegistry, cfg *config.Config, prefix string, opts indexOptions) gin.H {
+	opts.prefix = prefix
+	if !opts.query.Has("view") {
+		opts.view = treeView
+	}
+	data := indexPageData(problemRegistry, cfg, opts)
+	data["section"] = prefix
+	data["breadcrumbs"] = breadcrumbs(cfg, prefix)
+	data["canonicalURL"] = canonicalURL(cfg, prefix)
+	return data
+}
+
+// listedProblems returns problems shown on the index page, which leaves out
+// deprecated ones if configured so.
+func listedProblems(problemRegistry *problems.ProblemRegistry, cfg *config.Config, opts indexOptions) iter.Seq[*problems.ProblemConfig] {
+	return func(yield func(*problems.ProblemConfig) bool) {
+		for p := range problemRegistry.Sorted() {
+			if p.Deprecated && cfg.HideDeprecated || !opts.filter.Matches(p) {
+				continue
+			}
+			if opts.prefix != "" && !strings.HasPrefix(p.ID, opts.prefix+"/") {
+				continue
+			}
+			if !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// isSection reports whether an ID path prefix has problems under it.
+func isSection(problemRegistry *problems.ProblemRegistry, prefix string) bool {
+	for id := range problemRegistry.All() {
+		if strings.HasPrefix(id, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// sections returns the ID path prefixes that are not problem IDs themselves,
+// in lexical order, which get a landing page.
+func sections(problemRegistry *problems.ProblemRegistry) []string {
+	var prefixes []string
+	for id := range problemRegistry.All() {
+		for i := range len(id) {
+			if id[i] != '/' {
+				continue
+			}
+			prefix := id[:i]
+			if _, exists := problemRegistry.Get(prefix); !exists && !slices.Contains(prefixes, prefix) {
+				prefixes = append(prefixes, prefix)
+			}
+		}
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
+
+// treeNode is a segment of problem IDs in the tree view. Nodes with children
+// are sections, and nodes with a problem are the pages of problems, both at
+// once if a problem ID is a prefix of others.
+type treeNode struct {
+	Segment  string
+	Href     string
+	Problem  *problems.ProblemConfig
+	Children []*treeNode
+}
+
+// problemTree arranges problems by the segments of their IDs below prefix,
+// ordering siblings by segment.
+func problemTree(seq iter.Seq[*problems.ProblemConfig], prefix string, cfg *config.Config) []*treeNode {
+	root := &treeNode{}
+	for p := range seq {
+		node, section := root, prefix
+		for _, segment := range strings.Split(strings.TrimPrefix(p.ID, prefix+"/"), "/") {
+			if section == "" {
+				section = segment
+			} else {
+				section += "/" + segment
+			}
+
+			i := slices.IndexFunc(node.Children, func(child *treeNode) bool { return child.Segment == segment })
+			if i < 0 {
+				i = len(node.Children)
+				node.Children = append(node.Children, &treeNode{Segment: segment, Href: pageHref(cfg, section)})
+			}
+			node = node.Children[i]
+		}
+		node.Problem = p
+	}
+	sortTree(root.Children)
+	return root.Children
+}
+
+func sortTree(nodes []*treeNode) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Segment < nodes[j].Segment })
+	for _, node := range nodes {
+		sortTree(node.Children)
+	}
+}
+
+// breadcrumb is a link to an ancestor of a page, by ID path prefix.
+type breadcrumb struct {
+	Name string
+	Href string
+}
+
+// breadcrumbs returns links to the ancestors of an ID, like "validation" for
+// "validation/constraint-violation", leaving out the ID itself.
+func breadcrumbs(cfg *config.Config, id string) []breadcrumb {
+	segments := strings.Split(id, "/")
+	crumbs := make([]breadcrumb, 0, len(segments)-1)
+	for i := range len(segments) - 1 {
+		crumbs = append(crumbs, breadcrumb{
+			Name: segments[i],
+			Href: pageHref(cfg, strings.Join(segments[:i+1], "/")),
+		})
+	}
+	return crumbs
+}
+
+// pageHref returns the link to a page relative to the base path.
+func pageHref(cfg *config.Config, page string) string {
+	return strings.TrimSuffix(cfg.BaseHref, "/") + "/" + page
+}
+
+// categoryGroup is a section of the index page.
+type categoryGroup struct {
+	Name     string
+	Problems []*problems.ProblemConfig
+}
+
+// groupByCategory groups problems by category in lexical order, keeping the
+// order of problems within a category, and puts uncategorized ones last. It
+// also returns the number of problems.
+func groupByCategory(seq iter.Seq[*problems.ProblemConfig]) ([]categoryGroup, int) {
+	indexes := map[string]int{}
+	var groups []categoryGroup
+	count := 0
+	for p := range seq {
+		i, exists := indexes[p.Category]
+		if !exists {
+			i = len(groups)
+			indexes[p.Category] = i
+			groups = append(groups, categoryGroup{Name: p.Category})
+		}
+		groups[i].Problems = append(groups[i].Problems, p)
+		count++
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if (groups[i].Name == "") != (groups[j].Name == "") {
+			return groups[j].Name == ""
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	return groups, count
+}
+
+func problemPageData(problemRegistry *problems.ProblemRegistry, problem *problems.ProblemConfig, cfg *config.Config) gin.H {
+	var replacement *problems.ProblemConfig
+	if problem.ReplacedBy != "" {
+		replacement, _ = problemRegistry.Get(problem.ReplacedBy)
+	}
+
+	data := gin.H{
+		"problem":         problem,
+		"baseHref":        cfg.BaseHref,
+		"descriptionHTML": markdown.RenderToHTML(problem.Description),
+		"typeURI":         api.TypeURI(problem, cfg),
+		"canonicalURL":    canonicalURL(cfg, problem.ID),
+		"replacement":     replacement,
+		"breadcrumbs":     breadcrumbs(cfg, problem.ID),
+		"segment":         path.Base(problem.ID),
+	}
+	if problem.Category != "" {
+		data["categoryURL"] = cfg.BaseHref + "?" + url.Values{"category": {problem.Category}}.Encode()
+	}
+	return data
+}
+
+// canonicalURL returns the absolute URL of a page at path relative to the
+// documentation root, or an empty string if the public URL is not configured.
+func canonicalURL(cfg *config.Config, path string) string {
+	if cfg.PublicURL == "" {
+		return ""
+	}
+	return strings.TrimSuffix(cfg.PublicURL, "/") + "/" + path
+}
+
+func notFoundPageData(cfg *config.Config) gin.H {
+	return gin.H{"baseHref": cfg.BaseHref}
+}
+
+// gonePageData returns the template data of the page served in place of a
+// removed problem, with the problem replacing it if any.
+func gonePageData(problemRegistry *problems.ProblemRegistry, tombstone *problems.Tombstone, cfg *config.Config) gin.H {
+	var replacement *problems.ProblemConfig
+	if p, exists := problemRegistry.Get(tombstone.ReplacedBy); exists {
+		replacement = p
+	}
+	return gin.H{
+		"baseHref":    cfg.BaseHref,
+		"tombstone":   tombstone,
+		"replacement": replacement,
+	}
+}
@@ -0,0 +1,466 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+const testProblems = `version: "1"
+id: "404"
+title: "Not Found"
+status_code: 404
+---
+version: "1"
+id: "validation/constraint-violation"
+title: "Constraint Violation"
+status_code: 400
+category: "Validation"
+tags: ["client", "validation"]
+---
+version: "1"
+id: "legacy-not-found"
+title: "Not Found"
+status_code: 404
+deprecated: true
+replaced_by: "404"
+---
+version: "1"
+id: "legacy-missing"
+removed: "2025-03-01"
+reason: "Missing resources are reported as not found."
+replaced_by: "404"`
+
+func loadTestRegistry(t *testing.T) *problems.ProblemRegistry {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "problems.yaml"), []byte(testProblems), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	problemRegistry, err := problems.LoadFromDirectory(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to load problems: %v", err)
+	}
+	return problemRegistry
+}
+
+// newTestRouter routes problem pages the way serve does, with templates of
+// the repository root.
+func newTestRouter(t *testing.T, cfg *config.Config) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	problemRegistry := loadTestRegistry(t)
+
+	router := gin.New()
+	router.SetFuncMap(templateFuncs())
+	router.LoadHTMLGlob("../../templates/*")
+	router.GET("/", func(c *gin.Context) {
+		renderIndex(c, problemRegistry, cfg)
+	})
+	router.GET("/_tags/:tag", func(c *gin.Context) {
+		renderTag(c, problemRegistry, c.Param("tag"), cfg)
+	})
+	router.GET("/:id", func(c *gin.Context) {
+		renderProblem(c, problemRegistry, c.Param("id"), cfg)
+	})
+	router.GET("/:id/*wildcard", func(c *gin.Context) {
+		renderProblem(c, problemRegistry, c.Param("id")+c.Param("wildcard"), cfg)
+	})
+	return router
+}
+
+func TestProblemTree(t *testing.T) {
+	cfg := &config.Config{BaseHref: "/docs/"}
+	listed := []*problems.ProblemConfig{
+		{ID: "validation/field/required"},
+		{ID: "404"},
+		{ID: "validation/field"},
+		{ID: "validation/constraint-violation"},
+	}
+
+	t.Run("whole catalog", func(t *testing.T) {
+		tree := problemTree(slices.Values(listed), "", cfg)
+
+		if len(tree) != 2 || tree[0].Segment != "404" || tree[1].Segment != "validation" {
+			t.Fatalf("unexpected top level: %+v", tree)
+		}
+		section := tree[1]
+		if section.Problem != nil || section.Href != "/docs/validation" {
+			t.Errorf("unexpected section node: %+v", section)
+		}
+		if len(section.Children) != 2 || section.Children[0].Segment != "constraint-violation" || section.Children[1].Segment != "field" {
+			t.Fatalf("unexpected children: %+v", section.Children)
+		}
+		field := section.Children[1]
+		if field.Problem != listed[2] || field.Href != "/docs/validation/field" {
+			t.Errorf("expected problem node to also be a section: %+v", field)
+		}
+		if len(field.Children) != 1 || field.Children[0].Problem != listed[0] || field.Children[0].Href != "/docs/validation/field/required" {
+			t.Errorf("unexpected grandchildren: %+v", field.Children)
+		}
+	})
+
+	t.Run("below prefix", func(t *testing.T) {
+		tree := problemTree(slices.Values(listed[2:]), "validation", cfg)
+
+		if len(tree) != 2 || tree[0].Segment != "constraint-violation" || tree[1].Segment != "field" {
+			t.Fatalf("unexpected top level: %+v", tree)
+		}
+		if tree[0].Href != "/docs/validation/constraint-violation" {
+			t.Errorf("unexpected href: %s", tree[0].Href)
+		}
+	})
+}
+
+func TestSections(t *testing.T) {
+	problemRegistry := loadTestRegistry(t)
+
+	if got := sections(problemRegistry); !reflect.DeepEqual(got, []string{"validation"}) {
+		t.Errorf("expected sections [validation] but got %v", got)
+	}
+	if !isSection(problemRegistry, "validation") || isSection(problemRegistry, "valid") || isSection(problemRegistry, "404") {
+		t.Errorf("unexpected sections reported")
+	}
+}
+
+func TestBreadcrumbs(t *testing.T) {
+	tests := []struct {
+		id       string
+		baseHref string
+		expected []breadcrumb
+	}{
+		{"404", "/", []breadcrumb{}},
+		{"validation/constraint-violation", "/", []breadcrumb{{"validation", "/validation"}}},
+		{"validation/field/required", "/docs/", []breadcrumb{
+			{"validation", "/docs/validation"},
+			{"field", "/docs/validation/field"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got := breadcrumbs(&config.Config{BaseHref: tt.baseHref}, tt.id)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v but got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseIndexOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		indexView string
+		query     string
+		view      string
+		listURL   string
+		fails     bool
+	}{
+		{name: "default", indexView: listView, query: "", view: listView, listURL: "?view=list"},
+		{name: "configured default", indexView: treeView, query: "", view: treeView, listURL: "?view=list"},
+		{name: "requested view", indexView: treeView, query: "?view=list", view: listView, listURL: "?view=list"},
+		{name: "filters kept", indexView: listView, query: "?view=tree&tag=client", view: treeView, listURL: "?tag=client&view=list"},
+		{name: "unknown view", indexView: listView, query: "?view=grid", fails: true},
+		{name: "invalid filter", indexView: listView, query: "?status=abc", fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			opts, err := parseIndexOptions(c, &config.Config{IndexView: tt.indexView})
+			if tt.fails {
+				if err == nil {
+					t.Errorf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if opts.view != tt.view {
+				t.Errorf("expected view %q but got %q", tt.view, opts.view)
+			}
+			if opts.viewURL(listView) != tt.listURL {
+				t.Errorf("expected list view URL %q but got %q", tt.listURL, opts.viewURL(listView))
+			}
+		})
+	}
+}
+
+func TestRenderSection(t *testing.T) {
+	router := newTestRouter(t, &config.Config{BaseHref: "/", IndexView: listView})
+
+	tests := []struct {
+		name        string
+		path        string
+		accept      string
+		status      int
+		contentType string
+		contains    string
+	}{
+		{"html", "/validation", "text/html", http.StatusOK, "text/html", "Constraint Violation"},
+		{"trailing slash", "/validation/", "text/html", http.StatusOK, "text/html", "Constraint Violation"},
+		{"json", "/validation", "application/json", http.StatusNotFound, "application/problem+json", `"status":404`},
+		{"invalid view", "/validation?view=grid", "text/html", http.StatusBadRequest, "application/problem+json", "invalid view: grid"},
+		{"no section", "/valid", "text/html", http.StatusNotFound, "text/html", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Fatalf("expected status %d but got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+			if !strings.HasPrefix(w.Header().Get("Content-Type"), tt.contentType) {
+				t.Errorf("expected content type %s but got %s", tt.contentType, w.Header().Get("Content-Type"))
+			}
+			if !strings.Contains(w.Body.String(), tt.contains) {
+				t.Errorf("expected body to contain %q: %s", tt.contains, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestIndexView(t *testing.T) {
+	for view, expected := range map[string]string{
+		"list": listView,
+		"tree": treeView,
+		"":     listView,
+		"Tree": listView,
+	} {
+		if got := indexView(view); got != expected {
+			t.Errorf("expected view %q to be %q but got %q", view, expected, got)
+		}
+	}
+}
+
+func TestListedProblems(t *testing.T) {
+	problemRegistry := loadTestRegistry(t)
+
+	tests := []struct {
+		name        string
+		cfg         config.Config
+		expectedIDs []string
+	}{
+		{"deprecated listed", config.Config{}, []string{"validation/constraint-violation", "404", "legacy-not-found"}},
+		{"deprecated hidden", config.Config{HideDeprecated: true}, []string{"validation/constraint-violation", "404"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []string
+			for p := range listedProblems(problemRegistry, &tt.cfg, indexOptions{}) {
+				ids = append(ids, p.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.expectedIDs) {
+				t.Errorf("expected %v but got %v", tt.expectedIDs, ids)
+			}
+		})
+	}
+}
+
+func TestRenderDeprecated(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.Config
+		path     string
+		contains string
+		excludes string
+	}{
+		{
+			name:     "banner",
+			path:     "/legacy-not-found",
+			contains: `Use <a href="/404">[404] Not Found</a> instead.`,
+		},
+		{
+			name:     "index badge",
+			path:     "/",
+			contains: `<span class="badge">Deprecated</span>`,
+		},
+		{
+			name:     "index hiding deprecated",
+			cfg:      config.Config{HideDeprecated: true},
+			path:     "/",
+			excludes: "legacy-not-found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.BaseHref, tt.cfg.IndexView = "/", listView
+			router := newTestRouter(t, &tt.cfg)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Accept", "text/html")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200 but got %d", w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tt.contains) {
+				t.Errorf("expected page to contain %q: %s", tt.contains, w.Body.String())
+			}
+			if tt.excludes != "" && strings.Contains(w.Body.String(), tt.excludes) {
+				t.Errorf("expected page not to contain %q", tt.excludes)
+			}
+		})
+	}
+}
+
+func TestGroupByCategory(t *testing.T) {
+	listed := []*problems.ProblemConfig{
+		{ID: "500"},
+		{ID: "payment-required", Category: "Billing"},
+		{ID: "404"},
+		{ID: "card-declined", Category: "Billing"},
+		{ID: "constraint-violation", Category: "Validation"},
+	}
+
+	groups, count := groupByCategory(slices.Values(listed))
+
+	if count != len(listed) {
+		t.Errorf("expected count %d but got %d", len(listed), count)
+	}
+	var got [][]string
+	for _, group := range groups {
+		ids := []string{group.Name}
+		for _, p := range group.Problems {
+			ids = append(ids, p.ID)
+		}
+		got = append(got, ids)
+	}
+	expected := [][]string{
+		{"Billing", "payment-required", "card-declined"},
+		{"Validation", "constraint-violation"},
+		{"", "500", "404"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected groups %v but got %v", expected, got)
+	}
+}
+
+func TestRenderIndex(t *testing.T) {
+	router := newTestRouter(t, &config.Config{BaseHref: "/", IndexView: listView})
+
+	tests := []struct {
+		name     string
+		path     string
+		status   int
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "grouped by category",
+			path:     "/",
+			status:   http.StatusOK,
+			contains: []string{"<h3>Validation</h3>", "<h3>Other</h3>", `href="/404"`},
+			excludes: []string{"matching problem"},
+		},
+		{
+			name:     "tag and status filters",
+			path:     "/?tag=client&status=4xx",
+			status:   http.StatusOK,
+			contains: []string{"Showing 1 matching problem.", `href="/validation/constraint-violation"`},
+			excludes: []string{"<h3>Other</h3>", `href="/404"`},
+		},
+		{
+			name:     "category filter",
+			path:     "/?category=Validation",
+			status:   http.StatusOK,
+			contains: []string{"Showing 1 matching problem.", "<h3>Validation</h3>"},
+			excludes: []string{"<h3>Other</h3>"},
+		},
+		{
+			name:     "no match",
+			path:     "/?tag=client&status=5xx",
+			status:   http.StatusOK,
+			contains: []string{"Showing 0 matching problems."},
+		},
+		{
+			name:   "invalid filter",
+			path:   "/?status=abc",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:     "tag page",
+			path:     "/_tags/validation",
+			status:   http.StatusOK,
+			contains: []string{"Problems Tagged <code>validation</code>", `class="tag active" href="/_tags/validation"`},
+			excludes: []string{`href="/404"`},
+		},
+		{
+			name:     "tag page with filters",
+			path:     "/_tags/client?status=5xx",
+			status:   http.StatusOK,
+			contains: []string{"Showing 0 matching problems."},
+		},
+		{
+			name:   "unknown tag",
+			path:   "/_tags/billing",
+			status: http.StatusNotFound,
+		},
+		{
+			name:     "category link of problem page",
+			path:     "/validation/constraint-violation",
+			status:   http.StatusOK,
+			contains: []string{`Category: <a href="/?category=Validation">Validation</a>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Accept", "text/html")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Fatalf("expected status %d but got %d", tt.status, w.Code)
+			}
+			for _, text := range tt.contains {
+				if !strings.Contains(w.Body.String(), text) {
+					t.Errorf("expected page to contain %q", text)
+				}
+			}
+			for _, text := range tt.excludes {
+				if strings.Contains(w.Body.String(), text) {
+					t.Errorf("expected page not to contain %q", text)
+				}
+			}
+		})
+	}
+}
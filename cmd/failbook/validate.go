@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/malczuuu/failbook/internal/api"
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+type payloadValidation struct {
+	File string `json:"file"`
+	api.ValidationResult
+}
+
+// runValidate exits with 1 if any payload does not conform to the catalog.
+func runValidate(cfg *config.Config, args []string) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	dir := flags.String("dir", cfg.ProblemsDir, "directory containing problem YAML files")
+	baseHref := flags.String("base-href", cfg.BaseHref, "base path of type URIs")
+	format := flags.String("format", "text", "output format, \"text\" or \"json\"")
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) == 0 || (*format != "text" && *format != "json") {
+		fmt.Fprintln(os.Stderr, "usage: failbook validate <payload.json>... [-dir dir] [-base-href path] [-format text|json]")
+		return 2
+	}
+
+	problemRegistry, err := problems.LoadFromDirectoryWithOptions(*dir, loadOptions(cfg.UnknownFields))
+	if err != nil {
+		log.Error().Err(err).Msg("failed to load error configurations")
+		return 1
+	}
+
+	validateCfg := *cfg
+	validateCfg.BaseHref = *baseHref
+
+	validations := []payloadValidation{}
+	for _, path := range positional {
+		data, err := readInput(path)
+		if err != nil {
+			log.Error().Err(err).Str("file", path).Msg("failed to read payload")
+			return 1
+		}
+
+		payload, err := api.DecodePayload(data)
+		if err != nil {
+			log.Error().Err(err).Str("file", path).Msg("failed to decode payload")
+			return 1
+		}
+
+		validations = append(validations, payloadValidation{
+			File:             path,
+			ValidationResult: api.ValidatePayload(problemRegistry, &validateCfg, payload),
+		})
+	}
+
+	var buf bytes.Buffer
+	if *format == "json" {
+		data, err := json.MarshalIndent(validations, "", "  ")
+		if err != nil {
+			log.Error().Err(err).Msg("failed to encode validation results")
+			return 1
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	} else {
+		for _, v := range validations {
+			if v.Valid {
+				fmt.Fprintf(&buf, "%s: valid (%s)\n", v.File, v.ProblemID)
+				continue
+			}
+			fmt.Fprintf(&buf, "%s: invalid\n", v.File)
+			for _, m := range v.Mismatches {
+				fmt.Fprintf(&buf, "  - %s: %s\n", m.Member, m.Message)
+			}
+		}
+	}
+	if err := writeOutput("-", buf.Bytes()); err != nil {
+		log.Error().Err(err).Msg("failed to write validation results")
+		return 1
+	}
+
+	for _, v := range validations {
+		if !v.Valid {
+			return 1
+		}
+	}
+	return 0
+}
+
+// readInput reads the file at path, or stdin if path is "-".
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
@@ -0,0 +1,339 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/httpcache"
+	"github.com/malczuuu/failbook/internal/markdown"
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// ProblemItem is the JSON representation of a problem configuration in
+// lists, extended with its canonical type URI.
+type ProblemItem struct {
+	*problems.ProblemConfig
+	TypeURI string `json:"type_uri"`
+}
+
+// Problem is the JSON representation of a single problem configuration.
+type Problem struct {
+	ProblemItem
+	DescriptionHTML template.HTML `json:"description_html"`
+}
+
+// ProblemPage is the JSON representation of a filtered and paginated list of
+// problem configurations.
+type ProblemPage struct {
+	Items []ProblemItem `json:"items"`
+	Page  int           `json:"page"`
+	Size  int           `json:"size"`
+	Total int           `json:"total"`
+}
+
+// RemovedProblem is the JSON representation of a tombstone, served with
+// 410 Gone at the URIs of a removed problem.
+type RemovedProblem struct {
+	*problems.Tombstone
+	TypeURI            string `json:"type_uri"`
+	ReplacementTypeURI string `json:"replacement_type_uri,omitempty"`
+}
+
+type statusRange struct {
+	min int
+	max int
+}
+
+// Filter selects problems by status code, tag and category, the same way for
+// the API and for the index page. Values of a parameter are alternatives, and
+// all parameters given must match.
+type Filter struct {
+	statuses   []statusRange
+	tags       []string
+	categories []string
+}
+
+// ParseFilter reads the status (exact code like 404 or class like 4xx), tag
+// and category query parameters. Each may be repeated, and status and tag
+// also accept comma-separated lists.
+func ParseFilter(c *gin.Context) (Filter, error) {
+	statuses, err := parseStatusRanges(c.QueryArray("status"))
+	if err != nil {
+		return Filter{}, err
+	}
+
+	var tags []string
+	for _, value := range c.QueryArray("tag") {
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	return Filter{statuses: statuses, tags: tags, categories: c.QueryArray("category")}, nil
+}
+
+// WithTag narrows a filter down to problems with the given tag.
+func (f Filter) WithTag(tag string) Filter {
+	f.tags = []string{tag}
+	return f
+}
+
+// Active reports whether the filter leaves out any problems.
+func (f Filter) Active() bool {
+	return len(f.statuses) > 0 || len(f.tags) > 0 || len(f.categories) > 0
+}
+
+func (f Filter) Matches(p *problems.ProblemConfig) bool {
+	if !matchesStatus(p.StatusCode, f.statuses) {
+		return false
+	}
+	if len(f.tags) > 0 && !slices.ContainsFunc(f.tags, func(tag string) bool { return slices.Contains(p.Tags, tag) }) {
+		return false
+	}
+	if len(f.categories) > 0 && !slices.Contains(f.categories, p.Category) {
+		return false
+	}
+	return true
+}
+
+// ListProblemsHandler serves GET /api/problems. Supported query parameters are
+// the ones of ParseFilter, sort (status, id, name or title, prefixed with "-"
+// for descending order), page and size.
+func ListProblemsHandler(store *problems.Store, cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		problemRegistry := store.Registry()
+
+		filter, err := ParseFilter(c)
+		if err != nil {
+			AbortWithProblem(c, problemRegistry, cfg, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		page, err := parsePositiveInt(c.Query("page"), 1, "page")
+		if err != nil {
+			AbortWithProblem(c, problemRegistry, cfg, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		size, err := parsePositiveInt(c.Query("size"), defaultPageSize, "size")
+		if err != nil {
+			AbortWithProblem(c, problemRegistry, cfg, http.StatusBadRequest, err.Error())
+			return
+		}
+		size = min(size, maxPageSize)
+
+		items := []*problems.ProblemConfig{}
+		for p := range problemRegistry.Sorted() {
+			if filter.Matches(p) {
+				items = append(items, p)
+			}
+		}
+
+		if err := sortProblems(items, c.Query("sort")); err != nil {
+			AbortWithProblem(c, problemRegistry, cfg, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Pages past the end are empty, checked by division so that huge
+		// page numbers cannot overflow the offset.
+		total := len(items)
+		start := total
+		if page-1 < (total+size-1)/size {
+			start = (page - 1) * size
+		}
+		end := min(start+size, total)
+
+		pageItems := []ProblemItem{}
+		for _, p := range items[start:end] {
+			pageItems = append(pageItems, NewProblemItem(p, cfg))
+		}
+
+		c.JSON(http.StatusOK, ProblemPage{
+			Items: pageItems,
+			Page:  page,
+			Size:  size,
+			Total: total,
+		})
+	}
+}
+
+// GetProblemHandler serves GET /api/problems/*id, so that IDs containing
+// slashes resolve the same way as on documentation pages.
+func GetProblemHandler(store *problems.Store, cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := strings.TrimPrefix(c.Param("id"), "/")
+
+		problemRegistry := store.Registry()
+		problem, exists := problemRegistry.Get(id)
+		if !exists {
+			if problem, exists := problemRegistry.ResolveAlias(id); exists {
+				RedirectPermanently(c, cfg, "/api/problems/"+problem.ID)
+				return
+			}
+			if tombstone, exists := problemRegistry.Tombstone(id); exists {
+				AbortWithGone(c, problemRegistry, cfg, tombstone)
+				return
+			}
+			AbortWithProblem(c, problemRegistry, cfg, http.StatusNotFound, fmt.Sprintf("problem not found: %s", id))
+			return
+		}
+
+		SetDeprecationHeaders(c, problemRegistry, cfg, problem)
+
+		if httpcache.NotModified(c, httpcache.Validators{
+			ETag:         fmt.Sprintf(`"%s"`, problemRegistry.ProblemDigest(problem.ID)),
+			LastModified: problemRegistry.ProblemModTime(problem.ID),
+			CacheControl: cfg.CacheControl,
+		}) {
+			return
+		}
+
+		c.JSON(http.StatusOK, NewProblem(problem, cfg))
+	}
+}
+
+func NewProblemItem(p *problems.ProblemConfig, cfg *config.Config) ProblemItem {
+	return ProblemItem{
+		ProblemConfig: p,
+		TypeURI:       TypeURI(p, cfg),
+	}
+}
+
+func NewProblem(p *problems.ProblemConfig, cfg *config.Config) Problem {
+	return Problem{
+		ProblemItem:     NewProblemItem(p, cfg),
+		DescriptionHTML: markdown.RenderToHTML(p.Description),
+	}
+}
+
+func NewRemovedProblem(problemRegistry *problems.ProblemRegistry, t *problems.Tombstone, cfg *config.Config) RemovedProblem {
+	removed := RemovedProblem{
+		Tombstone: t,
+		TypeURI:   typeURI(t.ID, cfg),
+	}
+	if replacement, exists := problemRegistry.Get(t.ReplacedBy); exists {
+		removed.ReplacementTypeURI = TypeURI(replacement, cfg)
+	}
+	return removed
+}
+
+// AbortWithGone responds with 410 Gone and the JSON representation of a
+// tombstone, linking the replacement of the removed problem if any.
+func AbortWithGone(c *gin.Context, problemRegistry *problems.ProblemRegistry, cfg *config.Config, t *problems.Tombstone) {
+	removed := NewRemovedProblem(problemRegistry, t, cfg)
+	if removed.ReplacementTypeURI != "" {
+		SetSuccessorLink(c, removed.ReplacementTypeURI)
+	}
+	c.AbortWithStatusJSON(http.StatusGone, removed)
+}
+
+func parseStatusRanges(values []string) ([]statusRange, error) {
+	var ranges []statusRange
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			v = strings.ToLower(strings.TrimSpace(v))
+			if v == "" {
+				continue
+			}
+
+			if len(v) == 3 && strings.HasSuffix(v, "xx") && v[0] >= '1' && v[0] <= '5' {
+				class := int(v[0]-'0') * 100
+				ranges = append(ranges, statusRange{min: class, max: class + 99})
+				continue
+			}
+
+			code, err := strconv.Atoi(v)
+			if err != nil || code < 100 || code > 599 {
+				return nil, fmt.Errorf("invalid status filter: %s", v)
+			}
+			ranges = append(ranges, statusRange{min: code, max: code})
+		}
+	}
+	return ranges, nil
+}
+
+func matchesStatus(statusCode int, ranges []statusRange) bool {
+	if len(ranges) == 0 {
+		return true
+	}
+	for _, r := range ranges {
+		if statusCode >= r.min && statusCode <= r.max {
+			return true
+		}
+	}
+	return false
+}
+
+func parsePositiveInt(value string, defaultValue int, name string) (int, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid %s: %s", name, value)
+	}
+	return n, nil
+}
+
+// sortProblems reorders items in place. Items are expected to already be in
+// the registry's default order, which is kept for an empty sort key and used
+// to break ties.
+func sortProblems(items []*problems.ProblemConfig, key string) error {
+	if key == "" {
+		return nil
+	}
+
+	descending := strings.HasPrefix(key, "-")
+	key = strings.TrimPrefix(key, "-")
+
+	var less func(a, b *problems.ProblemConfig) bool
+	switch key {
+	case "status":
+		less = func(a, b *problems.ProblemConfig) bool { return a.StatusCode < b.StatusCode }
+	case "id":
+		less = func(a, b *problems.ProblemConfig) bool { return a.ID < b.ID }
+	case "name":
+		less = func(a, b *problems.ProblemConfig) bool { return a.Name < b.Name }
+	case "title":
+		less = func(a, b *problems.ProblemConfig) bool { return a.Title < b.Title }
+	default:
+		return fmt.Errorf("invalid sort: %s", key)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if descending {
+			return less(items[j], items[i])
+		}
+		return less(items[i], items[j])
+	})
+	return nil
+}
@@ -0,0 +1,471 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+const testProblems = `version: "1"
+id: "400"
+title: "Bad Request"
+status_code: 400
+tags: ["client"]
+---
+version: "1"
+id: "404"
+aliases: ["not-found"]
+title: "Not Found"
+status_code: 404
+---
+version: "2"
+id: "validation/constraint-violation"
+title: "Constraint Violation"
+status_code: 400
+category: "Validation"
+tags: ["client", "validation"]
+description: "**Invalid** fields"
+extensions:
+  - name: "errors"
+    type: "array"
+    required: true
+  - name: "errors[].field"
+    type: "string"
+    example: "email"
+---
+version: "1"
+id: "500"
+title: "Internal Server Error"
+status_code: 500
+---
+version: "1"
+id: "legacy-error"
+title: "Internal Server Error"
+status_code: 500
+deprecated: true
+deprecated_since: "2025-01-15"
+sunset: "2026-06-30"
+replaced_by: "500"
+---
+version: "1"
+id: "legacy-timeout"
+removed: "2025-03-01"
+reason: "Timeouts are reported as internal server errors."
+replaced_by: "500"`
+
+func newTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "problems.yaml"), []byte(testProblems), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	store, err := problems.NewStore(tmpDir, problems.LoadOptions{})
+	if err != nil {
+		t.Fatalf("failed to load problems: %v", err)
+	}
+
+	cfg := config.Config{BaseHref: "/docs/", CacheControl: "no-cache"}
+
+	router := gin.New()
+	router.GET("/api/problems", ListProblemsHandler(store, &cfg))
+	router.GET("/api/problems/*id", GetProblemHandler(store, &cfg))
+	router.POST("/api/validate", ValidateHandler(store, &cfg))
+	router.GET("/resolve", ResolveHandler(store, &cfg))
+	return router
+}
+
+func TestListProblemsHandler(t *testing.T) {
+	router := newTestRouter(t)
+
+	tests := []struct {
+		name        string
+		query       string
+		status      int
+		expectedIDs []string
+		total       int
+	}{
+		{
+			name:        "default order",
+			query:       "",
+			status:      http.StatusOK,
+			expectedIDs: []string{"400", "validation/constraint-violation", "404", "500", "legacy-error"},
+			total:       5,
+		},
+		{
+			name:        "status class filter",
+			query:       "?status=4xx",
+			status:      http.StatusOK,
+			expectedIDs: []string{"400", "validation/constraint-violation", "404"},
+			total:       3,
+		},
+		{
+			name:        "exact status filters",
+			query:       "?status=404&status=500",
+			status:      http.StatusOK,
+			expectedIDs: []string{"404", "500", "legacy-error"},
+			total:       3,
+		},
+		{
+			name:        "tag filter",
+			query:       "?tag=validation",
+			status:      http.StatusOK,
+			expectedIDs: []string{"validation/constraint-violation"},
+			total:       1,
+		},
+		{
+			name:        "tag and status filters",
+			query:       "?tag=client,validation&status=4xx",
+			status:      http.StatusOK,
+			expectedIDs: []string{"400", "validation/constraint-violation"},
+			total:       2,
+		},
+		{
+			name:        "category filter",
+			query:       "?category=Validation&status=5xx",
+			status:      http.StatusOK,
+			expectedIDs: []string{},
+			total:       0,
+		},
+		{
+			name:        "descending sort by id",
+			query:       "?sort=-id",
+			status:      http.StatusOK,
+			expectedIDs: []string{"validation/constraint-violation", "legacy-error", "500", "404", "400"},
+			total:       5,
+		},
+		{
+			name:        "pagination",
+			query:       "?page=2&size=3",
+			status:      http.StatusOK,
+			expectedIDs: []string{"500", "legacy-error"},
+			total:       5,
+		},
+		{
+			name:        "page out of range",
+			query:       "?page=5&size=3",
+			status:      http.StatusOK,
+			expectedIDs: []string{},
+			total:       5,
+		},
+		{
+			name:        "page overflowing offset",
+			query:       "?page=9223372036854775807&size=500",
+			status:      http.StatusOK,
+			expectedIDs: []string{},
+			total:       5,
+		},
+		{
+			name:   "invalid status",
+			query:  "?status=abc",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid sort",
+			query:  "?sort=color",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid page",
+			query:  "?page=0",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/problems"+tt.query, nil))
+
+			if w.Code != tt.status {
+				t.Fatalf("expected status %d but got %d", tt.status, w.Code)
+			}
+			if tt.status != http.StatusOK {
+				if w.Header().Get("Content-Type") != ProblemMediaType {
+					t.Errorf("expected %s but got %s", ProblemMediaType, w.Header().Get("Content-Type"))
+				}
+				return
+			}
+
+			var page ProblemPage
+			if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if page.Total != tt.total {
+				t.Errorf("expected total %d but got %d", tt.total, page.Total)
+			}
+			if len(page.Items) != len(tt.expectedIDs) {
+				t.Fatalf("expected %d items but got %d", len(tt.expectedIDs), len(page.Items))
+			}
+			for i, id := range tt.expectedIDs {
+				if page.Items[i].ID != id {
+					t.Errorf("expected item %d to be %q but got %q", i, id, page.Items[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestGetProblemHandler(t *testing.T) {
+	router := newTestRouter(t)
+
+	t.Run("nested id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/problems/validation/constraint-violation", nil))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200 but got %d", w.Code)
+		}
+
+		var body map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body["id"] != "validation/constraint-violation" {
+			t.Errorf("unexpected id: %v", body["id"])
+		}
+		if body["type_uri"] != "/docs/validation/constraint-violation" {
+			t.Errorf("unexpected type_uri: %v", body["type_uri"])
+		}
+		if body["description_html"] != "<p><strong>Invalid</strong> fields</p>\n" {
+			t.Errorf("unexpected description_html: %q", body["description_html"])
+		}
+		if w.Header().Get("ETag") == "" {
+			t.Errorf("expected ETag header to be set")
+		}
+
+		extensions, _ := body["extensions"].([]any)
+		if len(extensions) != 2 {
+			t.Fatalf("expected 2 extensions but got %v", body["extensions"])
+		}
+		field, _ := extensions[1].(map[string]any)
+		if field["name"] != "errors[].field" || field["type"] != "string" || field["required"] != false || field["example"] != "email" {
+			t.Errorf("unexpected extension: %v", field)
+		}
+	})
+
+	t.Run("alias", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/problems/not-found?x=1", nil))
+
+		if w.Code != http.StatusMovedPermanently {
+			t.Fatalf("expected status 301 but got %d", w.Code)
+		}
+		if w.Header().Get("Location") != "/docs/api/problems/404?x=1" {
+			t.Errorf("unexpected Location: %q", w.Header().Get("Location"))
+		}
+	})
+
+	t.Run("deprecated", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/problems/legacy-error", nil))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200 but got %d", w.Code)
+		}
+		headers := map[string]string{
+			"Deprecation": "@1736899200",
+			"Sunset":      "Tue, 30 Jun 2026 00:00:00 GMT",
+			"Link":        `</docs/500>; rel="successor-version"`,
+		}
+		for name, expected := range headers {
+			if w.Header().Get(name) != expected {
+				t.Errorf("expected %s header %q but got %q", name, expected, w.Header().Get(name))
+			}
+		}
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/problems/legacy-timeout", nil))
+
+		if w.Code != http.StatusGone {
+			t.Fatalf("expected status 410 but got %d", w.Code)
+		}
+		expected := `{"version":"1","id":"legacy-timeout","removed":"2025-03-01","reason":"Timeouts are reported as internal server errors.","replaced_by":"500","type_uri":"/docs/legacy-timeout","replacement_type_uri":"/docs/500"}`
+		if w.Body.String() != expected {
+			t.Errorf("expected body %s but got %s", expected, w.Body.String())
+		}
+		if w.Header().Get("Link") != `</docs/500>; rel="successor-version"` {
+			t.Errorf("unexpected Link header: %q", w.Header().Get("Link"))
+		}
+	})
+
+	t.Run("unknown id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/problems/unknown", nil))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404 but got %d", w.Code)
+		}
+
+		var details ProblemDetails
+		if err := json.Unmarshal(w.Body.Bytes(), &details); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		expected := ProblemDetails{
+			Type:     "/docs/404",
+			Title:    "Not Found",
+			Status:   404,
+			Detail:   "problem not found: unknown",
+			Instance: "/api/problems/unknown",
+		}
+		if details != expected {
+			t.Errorf("expected %+v but got %+v", expected, details)
+		}
+	})
+}
+
+func TestSetDeprecationHeaders(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "problems.yaml"), []byte(testProblems), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	problemRegistry, err := problems.LoadFromDirectory(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to load problems: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		problem  problems.ProblemConfig
+		expected map[string]string
+	}{
+		{
+			name: "dated",
+			problem: problems.ProblemConfig{
+				ID: "legacy", Deprecated: true, DeprecatedSince: "2025-01-15", Sunset: "2026-06-30", ReplacedBy: "500",
+			},
+			expected: map[string]string{
+				"Deprecation": "@1736899200",
+				"Sunset":      "Tue, 30 Jun 2026 00:00:00 GMT",
+				"Link":        `</docs/500>; rel="successor-version"`,
+			},
+		},
+		{
+			name:     "undated",
+			problem:  problems.ProblemConfig{ID: "legacy", Deprecated: true},
+			expected: map[string]string{"Deprecation": "", "Sunset": "", "Link": ""},
+		},
+		{
+			name:     "not deprecated",
+			problem:  problems.ProblemConfig{ID: "404", DeprecatedSince: "2025-01-15"},
+			expected: map[string]string{"Deprecation": "", "Sunset": "", "Link": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			SetDeprecationHeaders(c, problemRegistry, &config.Config{BaseHref: "/docs/"}, &tt.problem)
+
+			for name, expected := range tt.expected {
+				if w.Header().Get(name) != expected {
+					t.Errorf("expected %s header %q but got %q", name, expected, w.Header().Get(name))
+				}
+			}
+		})
+	}
+}
+
+func TestResolveType(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "problems.yaml"), []byte(testProblems), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	problemRegistry, err := problems.LoadFromDirectory(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to load problems: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		cfg        config.Config
+		typeURI    string
+		expectedID string
+	}{
+		{"relative", config.Config{BaseHref: "/docs/"}, "/docs/404", "404"},
+		{"trailing slash", config.Config{BaseHref: "/docs"}, "/docs/validation/constraint-violation/", "validation/constraint-violation"},
+		{"any host without public URL", config.Config{BaseHref: "/docs/"}, "https://errors.example.com/docs/404", "404"},
+		{"outside base path", config.Config{BaseHref: "/docs/"}, "/404", ""},
+		{"public URL", config.Config{BaseHref: "/", PublicURL: "https://errors.example.com/docs/"}, "https://errors.example.com/docs/404", "404"},
+		{"public URL host case", config.Config{BaseHref: "/", PublicURL: "https://errors.example.com/docs"}, "http://ERRORS.example.com/docs/404/", "404"},
+		{"public URL other host", config.Config{BaseHref: "/", PublicURL: "https://errors.example.com/docs/"}, "https://other.example.com/docs/404", ""},
+		{"public URL with base path", config.Config{BaseHref: "/", PublicURL: "https://errors.example.com/docs/"}, "/404", "404"},
+		{"alias", config.Config{BaseHref: "/docs/"}, "/docs/not-found", "404"},
+		{"unknown", config.Config{BaseHref: "/docs/"}, "/docs/unknown", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem, exists := ResolveType(problemRegistry, &tt.cfg, tt.typeURI)
+			if tt.expectedID == "" {
+				if exists {
+					t.Errorf("expected no problem but got %q", problem.ID)
+				}
+				return
+			}
+			if !exists || problem.ID != tt.expectedID {
+				t.Errorf("expected problem %q but got %v", tt.expectedID, problem)
+			}
+		})
+	}
+}
+
+func TestResolveHandler(t *testing.T) {
+	router := newTestRouter(t)
+
+	tests := []struct {
+		query  string
+		status int
+		body   string
+	}{
+		{"?type=https://errors.example.com/docs/validation/constraint-violation", http.StatusOK,
+			`{"href":"/docs/validation/constraint-violation","id":"validation/constraint-violation","type_uri":"/docs/validation/constraint-violation"}`},
+		{"?type=/docs/unknown", http.StatusNotFound, ""},
+		{"?type=/docs/legacy-timeout", http.StatusGone,
+			`{"version":"1","id":"legacy-timeout","removed":"2025-03-01","reason":"Timeouts are reported as internal server errors.","replaced_by":"500","type_uri":"/docs/legacy-timeout","replacement_type_uri":"/docs/500"}`},
+		{"", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/resolve"+tt.query, nil))
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d but got %d", tt.status, w.Code)
+			}
+			if tt.body != "" && w.Body.String() != tt.body {
+				t.Errorf("expected body %s but got %s", tt.body, w.Body.String())
+			}
+		})
+	}
+}
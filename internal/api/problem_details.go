@@ -0,0 +1,214 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+const ProblemMediaType = "application/problem+json"
+
+// ProblemDetails is an RFC 9457 problem details object.
+type ProblemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// NewProblemDetails describes an error of Failbook itself. If the catalog
+// documents a problem whose ID equals the status code (like "404"), its type
+// URI and title are used, otherwise the type is "about:blank".
+func NewProblemDetails(problemRegistry *problems.ProblemRegistry, cfg *config.Config, status int, detail string, instance string) ProblemDetails {
+	details := ProblemDetails{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+	}
+
+	if problem, exists := problemRegistry.Get(strconv.Itoa(status)); exists {
+		details.Type = TypeURI(problem, cfg)
+		details.Title = problem.Title
+	}
+
+	return details
+}
+
+// TypeURI returns the canonical URI of a problem, which is the URI of its
+// documentation page. It is absolute if the public URL is configured, and
+// relative to the base path otherwise.
+func TypeURI(p *problems.ProblemConfig, cfg *config.Config) string {
+	return typeURI(p.ID, cfg)
+}
+
+func typeURI(id string, cfg *config.Config) string {
+	if cfg.PublicURL != "" {
+		return strings.TrimSuffix(cfg.PublicURL, "/") + "/" + id
+	}
+	return strings.TrimSuffix(cfg.BaseHref, "/") + "/" + id
+}
+
+// ResolveType returns the problem documented at a type URI, including former
+// type URIs listed as aliases. The URI is matched by its path, relative to
+// the path of the public URL or to the base path, ignoring a trailing slash.
+// Absolute URIs must have the host of the public URL, if it is configured,
+// and are otherwise accepted with any host, since services usually emit them
+// with the host Failbook is exposed at.
+func ResolveType(problemRegistry *problems.ProblemRegistry, cfg *config.Config, typeURI string) (*problems.ProblemConfig, bool) {
+	for _, id := range typeIDs(cfg, typeURI) {
+		if problem, exists := problemRegistry.Get(id); exists {
+			return problem, true
+		}
+		if problem, exists := problemRegistry.ResolveAlias(id); exists {
+			return problem, true
+		}
+	}
+	return nil, false
+}
+
+// ResolveRemovedType returns the tombstone of a removed problem at a type
+// URI, matched the same way as by ResolveType.
+func ResolveRemovedType(problemRegistry *problems.ProblemRegistry, cfg *config.Config, typeURI string) (*problems.Tombstone, bool) {
+	for _, id := range typeIDs(cfg, typeURI) {
+		if tombstone, exists := problemRegistry.Tombstone(id); exists {
+			return tombstone, true
+		}
+	}
+	return nil, false
+}
+
+// typeIDs returns the IDs a type URI may refer to, relative to the path of
+// the public URL first and to the base path next.
+func typeIDs(cfg *config.Config, typeURI string) []string {
+	u, err := url.Parse(typeURI)
+	if err != nil {
+		return nil
+	}
+
+	prefixes := []string{strings.TrimSuffix(cfg.BaseHref, "/") + "/"}
+	if cfg.PublicURL != "" {
+		public, err := url.Parse(cfg.PublicURL)
+		if err != nil {
+			return nil
+		}
+		if u.Host != "" && !strings.EqualFold(u.Host, public.Host) {
+			return nil
+		}
+		prefixes = append([]string{strings.TrimSuffix(public.Path, "/") + "/"}, prefixes...)
+	}
+
+	var ids []string
+	path := strings.TrimSuffix(u.Path, "/")
+	for _, prefix := range prefixes {
+		if id, ok := strings.CutPrefix(path, prefix); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// ResolveHandler serves GET /resolve, mapping the type query parameter to
+// the ID of the problem documented at it. Removed problems get 410 Gone.
+func ResolveHandler(store *problems.Store, cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		problemRegistry := store.Registry()
+
+		typeURI := c.Query("type")
+		if typeURI == "" {
+			AbortWithProblem(c, problemRegistry, cfg, http.StatusBadRequest, "missing type query parameter")
+			return
+		}
+
+		problem, exists := ResolveType(problemRegistry, cfg, typeURI)
+		if !exists {
+			if tombstone, exists := ResolveRemovedType(problemRegistry, cfg, typeURI); exists {
+				AbortWithGone(c, problemRegistry, cfg, tombstone)
+				return
+			}
+			AbortWithProblem(c, problemRegistry, cfg, http.StatusNotFound, fmt.Sprintf("unknown problem type: %s", typeURI))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"id":       problem.ID,
+			"type_uri": TypeURI(problem, cfg),
+			"href":     strings.TrimSuffix(cfg.BaseHref, "/") + "/" + problem.ID,
+		})
+	}
+}
+
+// SetDeprecationHeaders describes a deprecated problem with the Deprecation
+// (RFC 9745) and Sunset (RFC 8594) headers, and links its replacement as the
+// successor version. RFC 9745 defines Deprecation as a date only, so it is
+// left out for problems without a deprecation date.
+func SetDeprecationHeaders(c *gin.Context, problemRegistry *problems.ProblemRegistry, cfg *config.Config, p *problems.ProblemConfig) {
+	if !p.Deprecated {
+		return
+	}
+
+	if since, err := problems.ParseDate(p.DeprecatedSince); err == nil {
+		c.Header("Deprecation", fmt.Sprintf("@%d", since.Unix()))
+	}
+
+	if sunset, err := problems.ParseDate(p.Sunset); err == nil {
+		c.Header("Sunset", sunset.UTC().Format(http.TimeFormat))
+	}
+
+	if p.ReplacedBy != "" {
+		if replacement, exists := problemRegistry.Get(p.ReplacedBy); exists {
+			SetSuccessorLink(c, TypeURI(replacement, cfg))
+		}
+	}
+}
+
+// SetSuccessorLink links the problem type replacing a deprecated or removed
+// one.
+func SetSuccessorLink(c *gin.Context, typeURI string) {
+	c.Header("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, typeURI))
+}
+
+// RedirectPermanently redirects to a path relative to the base path, keeping
+// the query string. GET and HEAD requests get 301 Moved Permanently, others
+// 308 Permanent Redirect, so that clients repeat them with the same method.
+func RedirectPermanently(c *gin.Context, cfg *config.Config, path string) {
+	location := strings.TrimSuffix(cfg.BaseHref, "/") + path
+	if c.Request.URL.RawQuery != "" {
+		location += "?" + c.Request.URL.RawQuery
+	}
+
+	status := http.StatusPermanentRedirect
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		status = http.StatusMovedPermanently
+	}
+	c.Redirect(status, location)
+}
+
+// AbortWithProblem responds with an application/problem+json body.
+func AbortWithProblem(c *gin.Context, problemRegistry *problems.ProblemRegistry, cfg *config.Config, status int, detail string) {
+	c.Header("Content-Type", ProblemMediaType)
+	c.AbortWithStatusJSON(status, NewProblemDetails(problemRegistry, cfg, status, detail, c.Request.URL.Path))
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+// ValidationResult reports how a problem details payload conforms to the
+// problem documented at its type URI.
+type ValidationResult struct {
+	Valid      bool       `json:"valid"`
+	ProblemID  string     `json:"problem_id,omitempty"`
+	Mismatches []Mismatch `json:"mismatches"`
+}
+
+// Mismatch is a single difference between a payload and its documentation,
+// identified by the path of the member, like "errors[0].field".
+type Mismatch struct {
+	Member  string `json:"member"`
+	Message string `json:"message"`
+}
+
+// MaxPayloadSize is the largest request body accepted by ValidateHandler, in
+// bytes, which is plenty for problem details while bounding the memory a
+// single request can take.
+const MaxPayloadSize = 1 << 20
+
+// ValidateHandler serves POST /api/validate. It responds with a
+// ValidationResult, with 422 Unprocessable Entity if the payload does not
+// conform to the catalog, and 413 Content Too Large if it exceeds
+// MaxPayloadSize.
+func ValidateHandler(store *problems.Store, cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		problemRegistry := store.Registry()
+
+		data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MaxPayloadSize))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			AbortWithProblem(c, problemRegistry, cfg, http.StatusRequestEntityTooLarge,
+				fmt.Sprintf("request body exceeds %d bytes", maxBytesErr.Limit))
+			return
+		}
+		if err != nil {
+			AbortWithProblem(c, problemRegistry, cfg, http.StatusBadRequest, fmt.Sprintf("failed to read request body: %s", err))
+			return
+		}
+
+		payload, err := DecodePayload(data)
+		if err != nil {
+			AbortWithProblem(c, problemRegistry, cfg, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		result := ValidatePayload(problemRegistry, cfg, payload)
+		if !result.Valid {
+			c.JSON(http.StatusUnprocessableEntity, result)
+			return
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// DecodePayload decodes a JSON object, keeping numbers as json.Number so that
+// integers can be told apart from other numbers.
+func DecodePayload(data []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var payload map[string]any
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("payload is not a JSON object: %w", err)
+	}
+	if payload == nil {
+		return nil, fmt.Errorf("payload is not a JSON object")
+	}
+	return payload, nil
+}
+
+// ValidatePayload resolves the type member of a problem details payload and
+// compares the payload with the documented problem: status and title must
+// match, and required extension members must be present with their declared
+// types.
+func ValidatePayload(problemRegistry *problems.ProblemRegistry, cfg *config.Config, payload map[string]any) ValidationResult {
+	v := &payloadValidator{result: ValidationResult{Mismatches: []Mismatch{}}}
+
+	typeURI, ok := payload["type"].(string)
+	if !ok {
+		v.mismatch("type", "missing type member")
+		return v.result
+	}
+
+	problem, exists := ResolveType(problemRegistry, cfg, typeURI)
+	if !exists {
+		v.mismatch("type", fmt.Sprintf("unknown problem type: %s", typeURI))
+		return v.result
+	}
+	v.result.ProblemID = problem.ID
+
+	if status, ok := payload["status"]; !ok {
+		v.mismatch("status", "missing status member")
+	} else if n, ok := status.(json.Number); !ok || n.String() != strconv.Itoa(problem.StatusCode) {
+		v.mismatch("status", fmt.Sprintf("status %s differs from documented status_code %d", formatValue(status), problem.StatusCode))
+	}
+
+	if title, ok := payload["title"]; !ok {
+		v.mismatch("title", "missing title member")
+	} else if title != problem.Title {
+		v.mismatch("title", fmt.Sprintf("title %s differs from documented title %q", formatValue(title), problem.Title))
+	}
+
+	for _, ext := range problem.Extensions {
+		v.checkMember(payload, strings.Split(ext.Name, "."), "", ext)
+	}
+
+	v.result.Valid = len(v.result.Mismatches) == 0
+	return v.result
+}
+
+type payloadValidator struct {
+	result ValidationResult
+}
+
+func (v *payloadValidator) mismatch(member string, message string) {
+	for _, m := range v.result.Mismatches {
+		if m.Member == member && m.Message == message {
+			return
+		}
+	}
+	v.result.Mismatches = append(v.result.Mismatches, Mismatch{Member: member, Message: message})
+}
+
+// checkMember follows the path of an extension member through object, and
+// through every element of arrays for segments ending with "[]". Members
+// nested in absent objects are not reported, the absent object is, if it is
+// declared as required.
+func (v *payloadValidator) checkMember(object map[string]any, segments []string, path string, ext problems.Extension) {
+	name, isArray := strings.CutSuffix(segments[0], "[]")
+	last := len(segments) == 1
+	member := name
+	if path != "" {
+		member = path + "." + name
+	}
+
+	value, exists := object[name]
+	if !exists {
+		if last && ext.Required {
+			v.mismatch(member, "missing required extension member")
+		}
+		return
+	}
+
+	if !isArray {
+		v.checkValue(value, segments[1:], member, ext)
+		return
+	}
+
+	items, ok := value.([]any)
+	if !ok {
+		v.mismatch(member, fmt.Sprintf("expected array, got %s", jsonType(value)))
+		return
+	}
+	for i, item := range items {
+		v.checkValue(item, segments[1:], fmt.Sprintf("%s[%d]", member, i), ext)
+	}
+}
+
+func (v *payloadValidator) checkValue(value any, segments []string, member string, ext problems.Extension) {
+	if len(segments) == 0 {
+		if !matchesType(value, ext.Type) {
+			v.mismatch(member, fmt.Sprintf("expected %s, got %s", ext.Type, jsonType(value)))
+		}
+		return
+	}
+
+	object, ok := value.(map[string]any)
+	if !ok {
+		v.mismatch(member, fmt.Sprintf("expected object, got %s", jsonType(value)))
+		return
+	}
+	v.checkMember(object, segments, member, ext)
+}
+
+func matchesType(value any, typ string) bool {
+	if typ == "integer" {
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := n.Int64()
+		return err == nil
+	}
+	return jsonType(value) == typ
+}
+
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case json.Number, float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func formatValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
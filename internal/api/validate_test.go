@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateHandler(t *testing.T) {
+	router := newTestRouter(t)
+
+	tests := []struct {
+		name       string
+		payload    string
+		status     int
+		problemID  string
+		mismatches []Mismatch
+	}{
+		{
+			name:      "valid payload",
+			payload:   `{"type": "/docs/404", "title": "Not Found", "status": 404, "detail": "no such user"}`,
+			status:    http.StatusOK,
+			problemID: "404",
+		},
+		{
+			name:      "absolute type URI",
+			payload:   `{"type": "https://errors.example.com/docs/404", "title": "Not Found", "status": 404}`,
+			status:    http.StatusOK,
+			problemID: "404",
+		},
+		{
+			name:       "missing type",
+			payload:    `{"title": "Not Found", "status": 404}`,
+			status:     http.StatusUnprocessableEntity,
+			mismatches: []Mismatch{{"type", "missing type member"}},
+		},
+		{
+			name:       "unknown type",
+			payload:    `{"type": "/docs/unknown", "title": "Not Found", "status": 404}`,
+			status:     http.StatusUnprocessableEntity,
+			mismatches: []Mismatch{{"type", "unknown problem type: /docs/unknown"}},
+		},
+		{
+			name:      "status and title differ",
+			payload:   `{"type": "/docs/404", "title": "Missing", "status": 410}`,
+			status:    http.StatusUnprocessableEntity,
+			problemID: "404",
+			mismatches: []Mismatch{
+				{"status", "status 410 differs from documented status_code 404"},
+				{"title", `title "Missing" differs from documented title "Not Found"`},
+			},
+		},
+		{
+			name:      "missing status and title",
+			payload:   `{"type": "/docs/404"}`,
+			status:    http.StatusUnprocessableEntity,
+			problemID: "404",
+			mismatches: []Mismatch{
+				{"status", "missing status member"},
+				{"title", "missing title member"},
+			},
+		},
+		{
+			name:       "missing required extension",
+			payload:    `{"type": "/docs/validation/constraint-violation", "title": "Constraint Violation", "status": 400}`,
+			status:     http.StatusUnprocessableEntity,
+			problemID:  "validation/constraint-violation",
+			mismatches: []Mismatch{{"errors", "missing required extension member"}},
+		},
+		{
+			name:       "extension of wrong type",
+			payload:    `{"type": "/docs/validation/constraint-violation", "title": "Constraint Violation", "status": 400, "errors": [{"field": "email"}, {"field": 1}]}`,
+			status:     http.StatusUnprocessableEntity,
+			problemID:  "validation/constraint-violation",
+			mismatches: []Mismatch{{"errors[1].field", "expected string, got number"}},
+		},
+		{
+			name:      "valid extensions",
+			payload:   `{"type": "/docs/validation/constraint-violation", "title": "Constraint Violation", "status": 400, "errors": [{"field": "email"}, {}]}`,
+			status:    http.StatusOK,
+			problemID: "validation/constraint-violation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/validate", strings.NewReader(tt.payload)))
+
+			if w.Code != tt.status {
+				t.Fatalf("expected status %d but got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+
+			var result ValidationResult
+			if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if result.Valid != (tt.status == http.StatusOK) || result.ProblemID != tt.problemID {
+				t.Errorf("unexpected result: %+v", result)
+			}
+			if tt.mismatches == nil {
+				tt.mismatches = []Mismatch{}
+			}
+			if !reflect.DeepEqual(result.Mismatches, tt.mismatches) {
+				t.Errorf("expected mismatches %+v but got %+v", tt.mismatches, result.Mismatches)
+			}
+		})
+	}
+
+	t.Run("payload too large", func(t *testing.T) {
+		payload := `{"type": "/docs/404", "detail": "` + strings.Repeat("x", MaxPayloadSize) + `"}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/validate", strings.NewReader(payload)))
+
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("expected status 413 but got %d", w.Code)
+		}
+		if w.Header().Get("Content-Type") != ProblemMediaType {
+			t.Errorf("expected problem+json response but got %q", w.Header().Get("Content-Type"))
+		}
+	})
+
+	t.Run("malformed payload", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/validate", strings.NewReader(`[1, 2]`)))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 but got %d", w.Code)
+		}
+		if w.Header().Get("Content-Type") != ProblemMediaType {
+			t.Errorf("expected problem+json response but got %q", w.Header().Get("Content-Type"))
+		}
+	})
+}
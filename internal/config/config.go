@@ -16,6 +16,7 @@ package config
 
 import (
 	"os"
+	"time"
 )
 
 type Config struct {
@@ -25,7 +26,18 @@ type Config struct {
 	PrometheusEnabled bool
 	ProblemsDir       string
 	BaseHref          string
+	PublicURL         string
 	Version           string
+	WatchEnabled      bool
+	WatchInterval     time.Duration
+	WatchDebounce     time.Duration
+	ReloadEnabled     bool
+	CacheControl      string
+	UnknownFields     string
+	MockEnabled       bool
+	HideDeprecated    bool
+	IndexView         string
+	AncestorFallback  string
 }
 
 func Load() Config {
@@ -36,7 +48,18 @@ func Load() Config {
 		PrometheusEnabled: getenv("FAILBOOK_PROMETHEUS_ENABLED", "false") == "true",
 		ProblemsDir:       getenv("FAILBOOK_PROBLEM_DOCS_DIR", "./problem-docs"),
 		BaseHref:          getenv("FAILBOOK_BASE_HREF", "/"),
+		PublicURL:         getenv("FAILBOOK_PUBLIC_URL", ""),
 		Version:           getenv("FAILBOOK_VERSION", "unspecified"),
+		WatchEnabled:      getenv("FAILBOOK_WATCH_ENABLED", "false") == "true",
+		WatchInterval:     getenvDuration("FAILBOOK_WATCH_INTERVAL", 2*time.Second),
+		WatchDebounce:     getenvDuration("FAILBOOK_WATCH_DEBOUNCE", 1*time.Second),
+		ReloadEnabled:     getenv("FAILBOOK_RELOAD_ENABLED", "false") == "true",
+		CacheControl:      getenv("FAILBOOK_CACHE_CONTROL", "no-cache"),
+		UnknownFields:     getenv("FAILBOOK_UNKNOWN_FIELDS", "warn"),
+		MockEnabled:       getenv("FAILBOOK_MOCK_ENABLED", "false") == "true",
+		HideDeprecated:    getenv("FAILBOOK_HIDE_DEPRECATED", "false") == "true",
+		IndexView:         getenv("FAILBOOK_INDEX_VIEW", "list"),
+		AncestorFallback:  getenv("FAILBOOK_ANCESTOR_FALLBACK", "off"),
 	}
 }
 
@@ -47,3 +70,11 @@ func getenv(key string, defaultValue string) string {
 	}
 	return v
 }
+
+func getenvDuration(key string, defaultValue time.Duration) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || d <= 0 {
+		return defaultValue
+	}
+	return d
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package httpcache
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Validators describe a representation for conditional request handling.
+// A zero LastModified disables the Last-Modified header.
+type Validators struct {
+	ETag         string
+	LastModified time.Time
+	CacheControl string
+}
+
+// NotModified sets caching headers on the response and evaluates the request
+// preconditions as described in RFC 9110, section 13. If the client's cached
+// representation is still valid, it writes 304 Not Modified and returns true.
+func NotModified(c *gin.Context, v Validators) bool {
+	if v.ETag != "" {
+		c.Header("ETag", v.ETag)
+	}
+	if !v.LastModified.IsZero() {
+		c.Header("Last-Modified", v.LastModified.UTC().Format(http.TimeFormat))
+	}
+	if v.CacheControl != "" {
+		c.Header("Cache-Control", v.CacheControl)
+	}
+
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return false
+	}
+
+	if match := c.GetHeader("If-None-Match"); match != "" {
+		if !matchesETag(match, v.ETag) {
+			return false
+		}
+		c.Status(http.StatusNotModified)
+		return true
+	}
+
+	if since := c.GetHeader("If-Modified-Since"); since != "" && !v.LastModified.IsZero() {
+		t, err := http.ParseTime(since)
+		if err != nil || v.LastModified.Truncate(time.Second).After(t) {
+			return false
+		}
+		c.Status(http.StatusNotModified)
+		return true
+	}
+
+	return false
+}
+
+// matchesETag reports whether an If-None-Match header value matches etag,
+// using the weak comparison function required for that header.
+func matchesETag(header string, etag string) bool {
+	if etag == "" {
+		return false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		if weakTag(strings.TrimSpace(candidate)) == weakTag(etag) {
+			return true
+		}
+	}
+	return false
+}
+
+func weakTag(etag string) string {
+	return strings.TrimPrefix(etag, "W/")
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package httpcache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	lastModified := time.Date(2025, 10, 1, 12, 0, 0, 500, time.UTC)
+	validators := Validators{ETag: `"abc"`, LastModified: lastModified, CacheControl: "no-cache"}
+
+	tests := []struct {
+		name        string
+		headers     map[string]string
+		notModified bool
+	}{
+		{
+			name:        "no preconditions",
+			headers:     map[string]string{},
+			notModified: false,
+		},
+		{
+			name:        "matching etag",
+			headers:     map[string]string{"If-None-Match": `"abc"`},
+			notModified: true,
+		},
+		{
+			name:        "matching etag in list",
+			headers:     map[string]string{"If-None-Match": `"xyz", "abc"`},
+			notModified: true,
+		},
+		{
+			name:        "weak etag matches",
+			headers:     map[string]string{"If-None-Match": `W/"abc"`},
+			notModified: true,
+		},
+		{
+			name:        "wildcard",
+			headers:     map[string]string{"If-None-Match": `*`},
+			notModified: true,
+		},
+		{
+			name:        "different etag",
+			headers:     map[string]string{"If-None-Match": `"xyz"`},
+			notModified: false,
+		},
+		{
+			name:        "if-none-match takes precedence over if-modified-since",
+			headers:     map[string]string{"If-None-Match": `"xyz"`, "If-Modified-Since": lastModified.Format(http.TimeFormat)},
+			notModified: false,
+		},
+		{
+			name:        "not modified since",
+			headers:     map[string]string{"If-Modified-Since": lastModified.Format(http.TimeFormat)},
+			notModified: true,
+		},
+		{
+			name:        "modified since",
+			headers:     map[string]string{"If-Modified-Since": lastModified.Add(-time.Hour).Format(http.TimeFormat)},
+			notModified: false,
+		},
+		{
+			name:        "invalid if-modified-since",
+			headers:     map[string]string{"If-Modified-Since": "yesterday"},
+			notModified: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tt.headers {
+				c.Request.Header.Set(k, v)
+			}
+
+			if got := NotModified(c, validators); got != tt.notModified {
+				t.Errorf("expected %v but got %v", tt.notModified, got)
+			}
+			if w.Header().Get("ETag") != `"abc"` {
+				t.Errorf("expected ETag header to be set")
+			}
+			if w.Header().Get("Last-Modified") != lastModified.Format(http.TimeFormat) {
+				t.Errorf("expected Last-Modified header to be set, got %q", w.Header().Get("Last-Modified"))
+			}
+			if w.Header().Get("Cache-Control") != "no-cache" {
+				t.Errorf("expected Cache-Control header to be set")
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package jsonschema
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/malczuuu/failbook/internal/api"
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+const (
+	Dialect   = "https://json-schema.org/draft/2020-12/schema"
+	MediaType = "application/schema+json"
+
+	// Suffix is appended to a problem page path to get its schema.
+	Suffix = ".schema.json"
+)
+
+// Schema is the subset of JSON Schema used by generated schemas.
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	ID          string             `json:"$id,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Const       any                `json:"const,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Examples    []any              `json:"examples,omitempty"`
+	OneOf       []*Schema          `json:"oneOf,omitempty"`
+	Defs        map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// SchemaURI returns the URI the schema of a problem is served at.
+func SchemaURI(p *problems.ProblemConfig, cfg *config.Config) string {
+	return api.TypeURI(p, cfg) + Suffix
+}
+
+// BundleURI returns the URI the catalog-wide schema is served at.
+func BundleURI(cfg *config.Config) string {
+	return strings.TrimSuffix(cfg.BaseHref, "/") + "/api/schema.json"
+}
+
+// Generate builds a schema of the application/problem+json body of a problem,
+// with type, title and status fixed to the documented values, and declared
+// extension members.
+func Generate(p *problems.ProblemConfig, cfg *config.Config) *Schema {
+	schema := &Schema{
+		Schema:      Dialect,
+		ID:          SchemaURI(p, cfg),
+		Title:       p.Name,
+		Description: p.Summary,
+		Type:        "object",
+		Properties: map[string]*Schema{
+			"type": {
+				Type:        "string",
+				Format:      "uri-reference",
+				Description: "URI reference identifying the problem type.",
+				Const:       api.TypeURI(p, cfg),
+			},
+			"title": {
+				Type:        "string",
+				Description: "Short, human-readable summary of the problem type.",
+				Const:       p.Title,
+			},
+			"status": {
+				Type:        "integer",
+				Description: "HTTP status code generated by the origin server.",
+				Const:       p.StatusCode,
+			},
+			"detail": {
+				Type:        "string",
+				Description: "Human-readable explanation specific to this occurrence of the problem.",
+			},
+			"instance": {
+				Type:        "string",
+				Format:      "uri-reference",
+				Description: "URI reference identifying the specific occurrence of the problem.",
+			},
+		},
+		Required: []string{"type", "title", "status"},
+	}
+
+	for _, ext := range p.Extensions {
+		addExtension(schema, ext)
+	}
+	return schema
+}
+
+// GenerateBundle builds a schema matching the body of any problem in the
+// catalog, with the schema of each problem under $defs.
+func GenerateBundle(problemRegistry *problems.ProblemRegistry, cfg *config.Config) *Schema {
+	bundle := &Schema{
+		Schema: Dialect,
+		ID:     BundleURI(cfg),
+		Title:  "Failbook Problem Catalog",
+		OneOf:  []*Schema{},
+		Defs:   map[string]*Schema{},
+	}
+
+	for p := range problemRegistry.Sorted() {
+		schema := Generate(p, cfg)
+		schema.Schema = ""
+		bundle.Defs[p.ID] = schema
+		bundle.OneOf = append(bundle.OneOf, &Schema{Ref: "#/$defs/" + escapePointer(p.ID)})
+	}
+	return bundle
+}
+
+// addExtension adds the schema of an extension member, creating the objects
+// and arrays on its path, like "errors" for "errors[].field", unless they are
+// declared themselves.
+func addExtension(root *Schema, ext problems.Extension) {
+	segments := strings.Split(ext.Name, ".")
+	parent := root
+
+	for i, segment := range segments {
+		name, isArray := strings.CutSuffix(segment, "[]")
+
+		if parent.Properties == nil {
+			parent.Properties = map[string]*Schema{}
+		}
+		property := parent.Properties[name]
+		if property == nil {
+			property = &Schema{}
+			parent.Properties[name] = property
+		}
+
+		target := property
+		if isArray {
+			property.Type = "array"
+			if property.Items == nil {
+				property.Items = &Schema{}
+			}
+			target = property.Items
+		}
+
+		if i == len(segments)-1 {
+			target.Type = ext.Type
+			target.Description = ext.Description
+			if ext.Example != nil {
+				target.Examples = []any{ext.Example}
+			}
+			if ext.Required && !slices.Contains(parent.Required, name) {
+				parent.Required = append(parent.Required, name)
+			}
+			return
+		}
+
+		if target.Type == "" {
+			target.Type = "object"
+		}
+		parent = target
+	}
+}
+
+// escapePointer escapes a JSON Pointer reference token (RFC 6901).
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+const testProblems = `version: "1"
+id: "404"
+title: "Not Found"
+status_code: 404
+---
+version: "2"
+id: "validation/constraint-violation"
+title: "Constraint Violation"
+status_code: 400
+extensions:
+  - name: "errors[].field"
+    type: "string"
+    required: true
+  - name: "errors"
+    type: "array"
+    required: true
+    description: "Violated constraints"
+  - name: "tags[]"
+    type: "string"
+    example: "input"
+  - name: "context.request_id"
+    type: "string"`
+
+func loadTestRegistry(t *testing.T) *problems.ProblemRegistry {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "problems.yaml"), []byte(testProblems), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	problemRegistry, err := problems.LoadFromDirectory(tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return problemRegistry
+}
+
+func TestGenerate(t *testing.T) {
+	problemRegistry := loadTestRegistry(t)
+	problem, _ := problemRegistry.Get("validation/constraint-violation")
+
+	schema := Generate(problem, &config.Config{BaseHref: "/docs/"})
+
+	if schema.Schema != Dialect || schema.ID != "/docs/validation/constraint-violation.schema.json" {
+		t.Errorf("unexpected schema header: %s %s", schema.Schema, schema.ID)
+	}
+	if schema.Properties["type"].Const != "/docs/validation/constraint-violation" ||
+		schema.Properties["title"].Const != "Constraint Violation" ||
+		schema.Properties["status"].Const != 400 {
+		t.Errorf("unexpected standard members: type=%v title=%v status=%v",
+			schema.Properties["type"].Const, schema.Properties["title"].Const, schema.Properties["status"].Const)
+	}
+	if !reflect.DeepEqual(schema.Required, []string{"type", "title", "status", "errors"}) {
+		t.Errorf("unexpected required members: %v", schema.Required)
+	}
+
+	data, err := json.Marshal(schema.Properties)
+	if err != nil {
+		t.Fatalf("failed to encode schema: %v", err)
+	}
+	var properties map[string]any
+	if err := json.Unmarshal(data, &properties); err != nil {
+		t.Fatalf("failed to decode schema: %v", err)
+	}
+
+	expected := map[string]string{
+		"errors":   `{"description":"Violated constraints","type":"array","items":{"type":"object","properties":{"field":{"type":"string"}},"required":["field"]}}`,
+		"tags":     `{"type":"array","items":{"type":"string","examples":["input"]}}`,
+		"context":  `{"type":"object","properties":{"request_id":{"type":"string"}}}`,
+		"detail":   `{"description":"Human-readable explanation specific to this occurrence of the problem.","type":"string"}`,
+		"instance": `{"description":"URI reference identifying the specific occurrence of the problem.","type":"string","format":"uri-reference"}`,
+	}
+	for name, want := range expected {
+		got, err := json.Marshal(properties[name])
+		if err != nil {
+			t.Fatalf("failed to encode property: %v", err)
+		}
+		var wantValue, gotValue any
+		_ = json.Unmarshal([]byte(want), &wantValue)
+		_ = json.Unmarshal(got, &gotValue)
+		if !reflect.DeepEqual(wantValue, gotValue) {
+			t.Errorf("property %s: expected %s but got %s", name, want, got)
+		}
+	}
+}
+
+func TestGenerateBundle(t *testing.T) {
+	problemRegistry := loadTestRegistry(t)
+
+	bundle := GenerateBundle(problemRegistry, &config.Config{BaseHref: "/docs/"})
+
+	if bundle.ID != "/docs/api/schema.json" {
+		t.Errorf("unexpected bundle ID: %s", bundle.ID)
+	}
+
+	var refs []string
+	for _, s := range bundle.OneOf {
+		refs = append(refs, s.Ref)
+	}
+	expectedRefs := []string{"#/$defs/validation~1constraint-violation", "#/$defs/404"}
+	if !reflect.DeepEqual(refs, expectedRefs) {
+		t.Errorf("expected refs %v but got %v", expectedRefs, refs)
+	}
+
+	def := bundle.Defs["validation/constraint-violation"]
+	if def == nil {
+		t.Fatalf("expected definition of validation/constraint-violation")
+	}
+	if def.Schema != "" || def.Properties["status"].Const != 400 {
+		t.Errorf("unexpected definition: %+v", def)
+	}
+}
@@ -31,6 +31,13 @@ func ConfigureLogger(cfg *config.Config) {
 	log.Logger = zerolog.New(os.Stdout).Level(logLevel).With().Timestamp().Logger()
 }
 
+// ConfigureCommandLogger configures human-readable logging to stderr for
+// command line tools, so that it does not mix with their output.
+func ConfigureCommandLogger(cfg *config.Config) {
+	logLevel := parseLevel(cfg.LogLevel)
+	log.Logger = zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr, NoColor: true}).Level(logLevel).With().Timestamp().Logger()
+}
+
 func parseLevel(levelStr string) zerolog.Level {
 	logLevel, err := zerolog.ParseLevel(levelStr)
 	if err != nil {
@@ -34,8 +34,15 @@ var (
 		},
 		[]string{"method", "path"},
 	)
+	ProblemFallbacksTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "problem_fallbacks_total",
+			Help: "Total number of requests for undocumented problem IDs served by their nearest documented ancestor, labeled by ancestor, mode.",
+		},
+		[]string{"ancestor", "mode"},
+	)
 )
 
 func Init() {
-	prometheus.MustRegister(HTTPRequestsTotal, HTTPRequestDurationSeconds)
+	prometheus.MustRegister(HTTPRequestsTotal, HTTPRequestDurationSeconds, ProblemFallbacksTotal)
 }
@@ -0,0 +1,205 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package mock
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/malczuuu/failbook/internal/api"
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+// MaxDelay caps the delay query parameter, so that a mocked response cannot
+// hold a connection for longer.
+const MaxDelay = time.Minute
+
+// Handler serves GET and POST /_mock/*id with the documented response of a
+// problem. Query parameters delay (like "500ms") and retry_after (seconds)
+// slow down the response and set the Retry-After header. Removed problems get
+// 410 Gone with their tombstone, the same as from the API.
+func Handler(store *problems.Store, cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		problemRegistry := store.Registry()
+		id := strings.TrimPrefix(c.Param("id"), "/")
+
+		problem, exists := problemRegistry.Get(id)
+		if !exists {
+			if problem, exists := problemRegistry.ResolveAlias(id); exists {
+				api.RedirectPermanently(c, cfg, "/_mock/"+problem.ID)
+				return
+			}
+			if tombstone, exists := problemRegistry.Tombstone(id); exists {
+				api.AbortWithGone(c, problemRegistry, cfg, tombstone)
+				return
+			}
+			api.AbortWithProblem(c, problemRegistry, cfg, http.StatusNotFound, fmt.Sprintf("problem not found: %s", id))
+			return
+		}
+
+		delay, err := parseDelay(c.Query("delay"))
+		if err != nil {
+			api.AbortWithProblem(c, problemRegistry, cfg, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if retryAfter := c.Query("retry_after"); retryAfter != "" {
+			if seconds, err := strconv.Atoi(retryAfter); err != nil || seconds < 0 {
+				api.AbortWithProblem(c, problemRegistry, cfg, http.StatusBadRequest, fmt.Sprintf("invalid retry_after: %s", retryAfter))
+				return
+			}
+			c.Header("Retry-After", retryAfter)
+		}
+
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-c.Request.Context().Done():
+				return
+			}
+		}
+
+		c.Header("Content-Type", api.ProblemMediaType)
+		c.JSON(problem.StatusCode, NewBody(problem, cfg, c.Request.URL.Path))
+	}
+}
+
+func parseDelay(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	delay, err := time.ParseDuration(value)
+	if err != nil || delay < 0 {
+		return 0, fmt.Errorf("invalid delay: %s", value)
+	}
+	if delay > MaxDelay {
+		return 0, fmt.Errorf("delay must not exceed %s: %s", MaxDelay, value)
+	}
+	return delay, nil
+}
+
+// NewBody builds the problem details body a service would respond with,
+// using the summary as detail and examples of extension members. Required
+// members without an example get the zero value of their type, so that the
+// body conforms to the problem's schema.
+func NewBody(p *problems.ProblemConfig, cfg *config.Config, instance string) map[string]any {
+	body := map[string]any{
+		"type":     api.TypeURI(p, cfg),
+		"title":    p.Title,
+		"status":   p.StatusCode,
+		"instance": instance,
+	}
+	if p.Summary != "" {
+		body["detail"] = p.Summary
+	}
+
+	for _, ext := range p.Extensions {
+		value := copyValue(ext.Example)
+		if value == nil {
+			if !ext.Required {
+				continue
+			}
+			value = zeroValue(ext.Type)
+		}
+		setMember(body, strings.Split(ext.Name, "."), value)
+	}
+	return body
+}
+
+// setMember sets a member at a path like "errors[].field", creating objects
+// and single-element arrays on the way. Values already set, like examples of
+// enclosing members, are never overwritten, but missing members are added to
+// every element of their arrays.
+func setMember(object map[string]any, segments []string, value any) {
+	name, isArray := strings.CutSuffix(segments[0], "[]")
+	last := len(segments) == 1
+
+	if !isArray {
+		if last {
+			if _, exists := object[name]; !exists {
+				object[name] = value
+			}
+			return
+		}
+		if _, exists := object[name]; !exists {
+			object[name] = map[string]any{}
+		}
+		if nested, ok := object[name].(map[string]any); ok {
+			setMember(nested, segments[1:], value)
+		}
+		return
+	}
+
+	if _, exists := object[name]; !exists {
+		if last {
+			object[name] = []any{value}
+			return
+		}
+		object[name] = []any{map[string]any{}}
+	}
+	if last {
+		return
+	}
+	items, _ := object[name].([]any)
+	for _, item := range items {
+		if nested, ok := item.(map[string]any); ok {
+			setMember(nested, segments[1:], value)
+		}
+	}
+}
+
+func zeroValue(typ string) any {
+	switch typ {
+	case "string":
+		return ""
+	case "number", "integer":
+		return 0
+	case "boolean":
+		return false
+	case "object":
+		return map[string]any{}
+	case "array":
+		return []any{}
+	default:
+		return nil
+	}
+}
+
+// copyValue deep copies a value decoded from YAML, so that building a body
+// never modifies examples held by the registry.
+func copyValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(v))
+		for key, item := range v {
+			copied[key] = copyValue(item)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(v))
+		for i, item := range v {
+			copied[i] = copyValue(item)
+		}
+		return copied
+	default:
+		return v
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+const testProblems = `version: "1"
+id: "503"
+aliases: ["unavailable"]
+title: "Service Unavailable"
+status_code: 503
+summary: "Try again later"
+---
+version: "2"
+id: "validation/constraint-violation"
+title: "Constraint Violation"
+status_code: 400
+extensions:
+  - name: "errors"
+    type: "array"
+    required: true
+    example:
+      - field: "email"
+  - name: "errors[].message"
+    type: "string"
+    required: true
+  - name: "errors[].code"
+    type: "string"
+    example: "not_blank"
+  - name: "context.request_id"
+    type: "string"
+    example: "abc"
+  - name: "retryable"
+    type: "boolean"
+---
+version: "1"
+id: "overloaded"
+removed: "2025-03-01"
+reason: "Overload is reported as service unavailable."
+replaced_by: "503"`
+
+func newTestRouter(t *testing.T) (*gin.Engine, *problems.Store) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "problems.yaml"), []byte(testProblems), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	store, err := problems.NewStore(tmpDir, problems.LoadOptions{})
+	if err != nil {
+		t.Fatalf("failed to load problems: %v", err)
+	}
+
+	cfg := config.Config{BaseHref: "/docs/"}
+
+	router := gin.New()
+	router.GET("/_mock/*id", Handler(store, &cfg))
+	router.POST("/_mock/*id", Handler(store, &cfg))
+	return router, store
+}
+
+func TestNewBody(t *testing.T) {
+	_, store := newTestRouter(t)
+	problem, _ := store.Registry().Get("validation/constraint-violation")
+
+	body := NewBody(problem, &config.Config{BaseHref: "/docs/"}, "/_mock/validation/constraint-violation")
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("failed to encode body: %v", err)
+	}
+	var got, expected any
+	_ = json.Unmarshal(data, &got)
+	_ = json.Unmarshal([]byte(`{
+		"type": "/docs/validation/constraint-violation",
+		"title": "Constraint Violation",
+		"status": 400,
+		"instance": "/_mock/validation/constraint-violation",
+		"errors": [{"field": "email", "message": "", "code": "not_blank"}],
+		"context": {"request_id": "abc"}
+	}`), &expected)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v but got %s", expected, data)
+	}
+
+	if len(problem.Extensions[0].Example.([]any)[0].(map[string]any)) != 1 {
+		t.Errorf("expected example in registry to be left intact")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	router, _ := newTestRouter(t)
+
+	t.Run("alias", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/_mock/unavailable?retry_after=5", nil))
+
+		if w.Code != http.StatusPermanentRedirect {
+			t.Fatalf("expected status 308 but got %d", w.Code)
+		}
+		if w.Header().Get("Location") != "/docs/_mock/503?retry_after=5" {
+			t.Errorf("unexpected Location: %q", w.Header().Get("Location"))
+		}
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/_mock/overloaded", nil))
+
+		if w.Code != http.StatusGone {
+			t.Fatalf("expected status 410 but got %d", w.Code)
+		}
+		if w.Header().Get("Link") != `</docs/503>; rel="successor-version"` {
+			t.Errorf("unexpected Link header: %q", w.Header().Get("Link"))
+		}
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		status     int
+		retryAfter string
+	}{
+		{"documented status", http.MethodGet, "/_mock/503", http.StatusServiceUnavailable, ""},
+		{"post with nested id", http.MethodPost, "/_mock/validation/constraint-violation", http.StatusBadRequest, ""},
+		{"retry after", http.MethodGet, "/_mock/503?retry_after=30&delay=1ms", http.StatusServiceUnavailable, "30"},
+		{"unknown problem", http.MethodGet, "/_mock/unknown", http.StatusNotFound, ""},
+		{"invalid delay", http.MethodGet, "/_mock/503?delay=soon", http.StatusBadRequest, ""},
+		{"delay over limit", http.MethodGet, "/_mock/503?delay=2m", http.StatusBadRequest, ""},
+		{"invalid retry after", http.MethodGet, "/_mock/503?retry_after=-1", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d but got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+			if w.Header().Get("Content-Type") != "application/problem+json" {
+				t.Errorf("unexpected content type: %q", w.Header().Get("Content-Type"))
+			}
+			if w.Header().Get("Retry-After") != tt.retryAfter {
+				t.Errorf("expected Retry-After %q but got %q", tt.retryAfter, w.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
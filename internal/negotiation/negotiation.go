@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package negotiation
+
+import (
+	"strconv"
+	"strings"
+)
+
+type mediaRange struct {
+	mainType string
+	subType  string
+	quality  float64
+}
+
+// Negotiate returns the offered media type that best matches the Accept
+// header, honoring quality values and wildcards. Ties are resolved in favor of
+// the earlier offer. An empty header accepts the first offer, and an empty
+// string is returned when none of the offers is acceptable.
+func Negotiate(accept string, offers ...string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	ranges := parseAccept(accept)
+
+	best := ""
+	bestQuality := 0.0
+	for _, offer := range offers {
+		if q := quality(offer, ranges); q > bestQuality {
+			best = offer
+			bestQuality = q
+		}
+	}
+	return best
+}
+
+// quality returns the quality value of the most specific media range matching
+// offer, or 0 if no range matches.
+func quality(offer string, ranges []mediaRange) float64 {
+	mainType, subType, _ := strings.Cut(offer, "/")
+
+	specificity := -1
+	q := 0.0
+	for _, r := range ranges {
+		var s int
+		switch {
+		case r.mainType == mainType && r.subType == subType:
+			s = 2
+		case r.mainType == mainType && r.subType == "*":
+			s = 1
+		case r.mainType == "*" && r.subType == "*":
+			s = 0
+		default:
+			continue
+		}
+		if s > specificity {
+			specificity = s
+			q = r.quality
+		}
+	}
+	return q
+}
+
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+		mainType, subType, ok := strings.Cut(mediaType, "/")
+		if !ok {
+			continue
+		}
+
+		r := mediaRange{mainType: mainType, subType: subType, quality: 1}
+		for _, param := range params[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.ToLower(strings.TrimSpace(key)) != "q" {
+				continue
+			}
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && q >= 0 && q <= 1 {
+				r.quality = q
+			}
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package negotiation
+
+import "testing"
+
+func TestNegotiate(t *testing.T) {
+	offers := []string{"text/html", "application/json", "text/markdown", "text/plain"}
+
+	tests := []struct {
+		name     string
+		accept   string
+		expected string
+	}{
+		{name: "empty header", accept: "", expected: "text/html"},
+		{name: "any", accept: "*/*", expected: "text/html"},
+		{name: "exact", accept: "application/json", expected: "application/json"},
+		{name: "browser", accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", expected: "text/html"},
+		{name: "quality values", accept: "text/html;q=0.5, application/json", expected: "application/json"},
+		{name: "subtype wildcard", accept: "text/*", expected: "text/html"},
+		{name: "specific range overrides wildcard", accept: "text/*, text/html;q=0", expected: "text/markdown"},
+		{name: "case insensitive", accept: "Text/Plain", expected: "text/plain"},
+		{name: "not acceptable", accept: "image/png", expected: ""},
+		{name: "zero quality", accept: "application/json;q=0", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.accept, offers...); got != tt.expected {
+				t.Errorf("expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
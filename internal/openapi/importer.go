@@ -0,0 +1,250 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+var operationMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// ExtractProblems finds error responses (4xx and 5xx) in an OpenAPI 3.x
+// document, given as YAML or JSON, and turns them into skeleton problem
+// configurations. Responses with problem examples carrying a type URI yield a
+// problem per type, others yield a generic problem per status code. Problems
+// are returned sorted by ID.
+//
+// IDs are derived from type URIs by stripping typePrefix (typically the public
+// URL of the documentation) or, if the URI does not start with it, by taking
+// the URI path.
+func ExtractProblems(data []byte, typePrefix string) ([]*problems.ProblemConfig, error) {
+	var document map[string]any
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+
+	version, _ := document["openapi"].(string)
+	if !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version: %q", version)
+	}
+
+	found := map[string]*problems.ProblemConfig{}
+
+	components, _ := document["components"].(map[string]any)
+	componentResponses, _ := components["responses"].(map[string]any)
+
+	paths, _ := document["paths"].(map[string]any)
+	for _, pathKey := range sortedKeys(paths) {
+		pathItem, _ := paths[pathKey].(map[string]any)
+		for _, method := range operationMethods {
+			operation, _ := pathItem[method].(map[string]any)
+			responses, _ := operation["responses"].(map[string]any)
+			for _, code := range sortedKeys(responses) {
+				status, ok := parseErrorStatus(code)
+				if !ok {
+					continue
+				}
+				response := resolveResponse(responses[code], componentResponses)
+				collectProblems(found, status, response, typePrefix)
+			}
+		}
+	}
+
+	// Reusable responses may not be referenced by any operation, or be shared
+	// among many of them. Their status code is known only from examples.
+	for _, name := range sortedKeys(componentResponses) {
+		response, _ := componentResponses[name].(map[string]any)
+		for _, example := range problemExamples(response) {
+			if status, ok := exampleStatus(example); ok {
+				collectProblems(found, status, response, typePrefix)
+				break
+			}
+		}
+	}
+
+	result := make([]*problems.ProblemConfig, 0, len(found))
+	for _, id := range sortedKeys(found) {
+		result = append(result, found[id])
+	}
+	return result, nil
+}
+
+func collectProblems(found map[string]*problems.ProblemConfig, status int, response map[string]any, typePrefix string) {
+	description, _ := response["description"].(string)
+	description = strings.TrimSpace(description)
+
+	typed := false
+	for _, example := range problemExamples(response) {
+		typeURI, _ := example["type"].(string)
+		id := problemIDFromType(typeURI, typePrefix)
+		if id == "" {
+			continue
+		}
+		typed = true
+
+		title, _ := example["title"].(string)
+		if title == "" {
+			title = http.StatusText(status)
+		}
+		exampleStatusCode := status
+		if s, ok := exampleStatus(example); ok {
+			exampleStatusCode = s
+		}
+		addProblem(found, id, title, exampleStatusCode, description)
+	}
+
+	if !typed {
+		addProblem(found, strconv.Itoa(status), http.StatusText(status), status, description)
+	}
+}
+
+func addProblem(found map[string]*problems.ProblemConfig, id string, title string, status int, summary string) {
+	if existing, exists := found[id]; exists {
+		if existing.Summary == "" {
+			existing.Summary = summary
+		}
+		return
+	}
+	if title == "" {
+		title = fmt.Sprintf("HTTP %d", status)
+	}
+	found[id] = &problems.ProblemConfig{
+		Version:    "1",
+		ID:         id,
+		Title:      title,
+		StatusCode: status,
+		Summary:    summary,
+	}
+}
+
+// parseErrorStatus accepts status codes and ranges (like "4XX") of client
+// and server errors, ranges being mapped to the generic 400 and 500 codes.
+func parseErrorStatus(code string) (int, bool) {
+	switch strings.ToUpper(code) {
+	case "4XX":
+		return http.StatusBadRequest, true
+	case "5XX":
+		return http.StatusInternalServerError, true
+	}
+	status, err := strconv.Atoi(code)
+	if err != nil || status < 400 || status > 599 {
+		return 0, false
+	}
+	return status, true
+}
+
+func resolveResponse(value any, componentResponses map[string]any) map[string]any {
+	response, _ := value.(map[string]any)
+	for i := 0; i < 10; i++ {
+		ref, _ := response["$ref"].(string)
+		name, ok := strings.CutPrefix(ref, "#/components/responses/")
+		if !ok {
+			return response
+		}
+		response, _ = componentResponses[name].(map[string]any)
+	}
+	return response
+}
+
+// problemExamples returns example bodies of JSON media types of a response,
+// both from "example" and "examples" fields.
+func problemExamples(response map[string]any) []map[string]any {
+	var examples []map[string]any
+
+	content, _ := response["content"].(map[string]any)
+	for _, mediaType := range sortedKeys(content) {
+		if !strings.Contains(mediaType, "json") {
+			continue
+		}
+		media, _ := content[mediaType].(map[string]any)
+
+		if example, ok := media["example"].(map[string]any); ok {
+			examples = append(examples, example)
+		}
+		named, _ := media["examples"].(map[string]any)
+		for _, name := range sortedKeys(named) {
+			entry, _ := named[name].(map[string]any)
+			if value, ok := entry["value"].(map[string]any); ok {
+				examples = append(examples, value)
+			}
+		}
+	}
+	return examples
+}
+
+func exampleStatus(example map[string]any) (int, bool) {
+	var status int
+	switch v := example["status"].(type) {
+	case uint64:
+		status = int(v)
+	case int64:
+		status = int(v)
+	case float64:
+		status = int(v)
+	case string:
+		status, _ = strconv.Atoi(v)
+	}
+	return status, status >= 400 && status <= 599
+}
+
+// problemIDFromType derives a problem ID from a type URI, so that the
+// documentation page of the problem resolves under the same path. IDs that
+// would not map to a file within the problems directory, like ones with ".."
+// segments leading out of it, are rejected, as stubs are written to files
+// named after them.
+func problemIDFromType(typeURI string, typePrefix string) string {
+	if typeURI == "" || typeURI == "about:blank" {
+		return ""
+	}
+
+	var id string
+	if rest, ok := strings.CutPrefix(typeURI, typePrefix); ok && typePrefix != "" {
+		id = strings.Trim(rest, "/")
+	} else {
+		u, err := url.Parse(typeURI)
+		if err != nil {
+			return ""
+		}
+		path := u.Path
+		if u.Opaque != "" {
+			path = u.Opaque
+		}
+		id = strings.Trim(path, "/")
+	}
+
+	if !filepath.IsLocal(filepath.FromSlash(id)) {
+		return ""
+	}
+	return id
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"testing"
+)
+
+const testSpec = `openapi: 3.0.3
+info:
+  title: Users
+  version: "1"
+paths:
+  /users:
+    post:
+      responses:
+        "201":
+          description: Created
+        "400":
+          description: Invalid user
+          content:
+            application/problem+json:
+              examples:
+                violation:
+                  value:
+                    type: https://errors.example.com/validation/constraint-violation
+                    title: Constraint Violation
+                    status: 400
+        "404":
+          $ref: "#/components/responses/NotFound"
+        5XX:
+          description: Server failure
+components:
+  responses:
+    NotFound:
+      description: User not found
+    Conflict:
+      description: User already exists
+      content:
+        application/problem+json:
+          example:
+            type: /users/conflict
+            title: User Conflict
+            status: 409
+`
+
+func TestExtractProblems(t *testing.T) {
+	stubs, err := ExtractProblems([]byte(testSpec), "https://errors.example.com/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		id      string
+		title   string
+		status  int
+		summary string
+	}{
+		{id: "404", title: "Not Found", status: 404, summary: "User not found"},
+		{id: "500", title: "Internal Server Error", status: 500, summary: "Server failure"},
+		{id: "users/conflict", title: "User Conflict", status: 409, summary: "User already exists"},
+		{id: "validation/constraint-violation", title: "Constraint Violation", status: 400, summary: "Invalid user"},
+	}
+
+	if len(stubs) != len(expected) {
+		t.Fatalf("expected %d problems but got %d", len(expected), len(stubs))
+	}
+	for i, e := range expected {
+		s := stubs[i]
+		if s.ID != e.id || s.Title != e.title || s.StatusCode != e.status || s.Summary != e.summary || s.Version != "1" {
+			t.Errorf("expected %+v but got %+v", e, *s)
+		}
+	}
+}
+
+func TestExtractProblems_UnsupportedVersion(t *testing.T) {
+	_, err := ExtractProblems([]byte(`swagger: "2.0"`), "")
+	if err == nil {
+		t.Errorf("expected error for Swagger 2.0 document")
+	}
+}
+
+func TestExtractProblems_TypeOutsideDirectory(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /users:
+    get:
+      responses:
+        "403":
+          description: Forbidden
+          content:
+            application/problem+json:
+              example:
+                type: https://evil.example/../../../../tmp/pwned
+                title: Pwned
+                status: 403
+`
+	stubs, err := ExtractProblems([]byte(spec), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stubs) != 1 || stubs[0].ID != "403" {
+		t.Fatalf("expected only a generic 403 problem but got %+v", stubs)
+	}
+}
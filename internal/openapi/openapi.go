@@ -0,0 +1,174 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/malczuuu/failbook/internal/api"
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+const (
+	Version = "3.1.0"
+
+	problemDetailsSchema = "ProblemDetails"
+)
+
+// Document is an OpenAPI document fragment containing only reusable
+// components, meant to be referenced from or merged into API specifications.
+type Document struct {
+	OpenAPI    string     `json:"openapi" yaml:"openapi"`
+	Info       Info       `json:"info" yaml:"info"`
+	Components Components `json:"components" yaml:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title" yaml:"title"`
+	Version string `json:"version" yaml:"version"`
+}
+
+type Components struct {
+	Schemas   map[string]*Schema   `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	Responses map[string]*Response `json:"responses,omitempty" yaml:"responses,omitempty"`
+}
+
+type Response struct {
+	Description string               `json:"description" yaml:"description"`
+	Content     map[string]MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+	StatusCode  int                  `json:"x-status-code,omitempty" yaml:"x-status-code,omitempty"`
+	ProblemID   string               `json:"x-problem-id,omitempty" yaml:"x-problem-id,omitempty"`
+}
+
+type MediaType struct {
+	Schema  *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Example any     `json:"example,omitempty" yaml:"example,omitempty"`
+}
+
+// Schema is the subset of JSON Schema used by generated documents.
+type Schema struct {
+	Ref         string             `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Type        string             `json:"type,omitempty" yaml:"type,omitempty"`
+	Format      string             `json:"format,omitempty" yaml:"format,omitempty"`
+	Description string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Const       any                `json:"const,omitempty" yaml:"const,omitempty"`
+	Enum        []any              `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Minimum     *int               `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum     *int               `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty" yaml:"required,omitempty"`
+	AllOf       []*Schema          `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+}
+
+var invalidComponentNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// Generate builds an OpenAPI document with one reusable response per problem
+// and a shared ProblemDetails schema, whose type member is restricted to the
+// documented type URIs.
+func Generate(problemRegistry *problems.ProblemRegistry, cfg *config.Config) *Document {
+	responses := map[string]*Response{}
+	typeURIs := []any{}
+
+	for p := range problemRegistry.Sorted() {
+		typeURI := api.TypeURI(p, cfg)
+		typeURIs = append(typeURIs, typeURI)
+
+		description := p.Summary
+		if description == "" {
+			description = p.Title
+		}
+
+		responses[uniqueComponentName(responses, p.ID)] = &Response{
+			Description: description,
+			StatusCode:  p.StatusCode,
+			ProblemID:   p.ID,
+			Content: map[string]MediaType{
+				api.ProblemMediaType: {
+					Schema: &Schema{
+						AllOf: []*Schema{
+							{Ref: "#/components/schemas/" + problemDetailsSchema},
+							{
+								Properties: map[string]*Schema{
+									"type":   {Const: typeURI},
+									"title":  {Const: p.Title},
+									"status": {Const: p.StatusCode},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	minStatus, maxStatus := 100, 599
+
+	return &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:   "Failbook Problem Catalog",
+			Version: cfg.Version,
+		},
+		Components: Components{
+			Schemas: map[string]*Schema{
+				problemDetailsSchema: {
+					Type:        "object",
+					Description: "Problem details as defined by RFC 9457.",
+					Properties: map[string]*Schema{
+						"type": {
+							Type:        "string",
+							Format:      "uri-reference",
+							Description: "URI reference identifying the problem type.",
+							Enum:        typeURIs,
+						},
+						"title": {
+							Type:        "string",
+							Description: "Short, human-readable summary of the problem type.",
+						},
+						"status": {
+							Type:        "integer",
+							Description: "HTTP status code generated by the origin server.",
+							Minimum:     &minStatus,
+							Maximum:     &maxStatus,
+						},
+						"detail": {
+							Type:        "string",
+							Description: "Human-readable explanation specific to this occurrence of the problem.",
+						},
+						"instance": {
+							Type:        "string",
+							Format:      "uri-reference",
+							Description: "URI reference identifying the specific occurrence of the problem.",
+						},
+					},
+				},
+			},
+			Responses: responses,
+		},
+	}
+}
+
+// uniqueComponentName turns a problem ID into a valid component name, which
+// may only contain letters, digits, dots, dashes and underscores.
+func uniqueComponentName(taken map[string]*Response, id string) string {
+	base := invalidComponentNameChars.ReplaceAllString(id, ".")
+	name := base
+	for i := 2; taken[name] != nil; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	return name
+}
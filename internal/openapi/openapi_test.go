@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/malczuuu/failbook/internal/config"
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+func TestGenerate(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := `version: "1"
+id: "404"
+title: "Not Found"
+status_code: 404
+summary: "Resource not found"
+---
+version: "1"
+id: "validation/constraint-violation"
+title: "Constraint Violation"
+status_code: 400`
+	if err := os.WriteFile(filepath.Join(tmpDir, "problems.yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	problemRegistry, err := problems.LoadFromDirectory(tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	document := Generate(problemRegistry, &config.Config{BaseHref: "/docs", Version: "1.2.3"})
+
+	if document.OpenAPI != "3.1.0" || document.Info.Version != "1.2.3" {
+		t.Errorf("unexpected document header: %+v", document)
+	}
+
+	typeSchema := document.Components.Schemas["ProblemDetails"].Properties["type"]
+	expectedTypes := []any{"/docs/validation/constraint-violation", "/docs/404"}
+	if len(typeSchema.Enum) != len(expectedTypes) {
+		t.Fatalf("expected type enum %v but got %v", expectedTypes, typeSchema.Enum)
+	}
+	for i := range expectedTypes {
+		if typeSchema.Enum[i] != expectedTypes[i] {
+			t.Errorf("expected type enum %v but got %v", expectedTypes, typeSchema.Enum)
+		}
+	}
+
+	notFound := document.Components.Responses["404"]
+	if notFound == nil {
+		t.Fatalf("expected response for 404")
+	}
+	if notFound.Description != "Resource not found" || notFound.StatusCode != 404 {
+		t.Errorf("unexpected response: %+v", notFound)
+	}
+
+	violation := document.Components.Responses["validation.constraint-violation"]
+	if violation == nil {
+		t.Fatalf("expected response for validation/constraint-violation")
+	}
+	if violation.Description != "Constraint Violation" {
+		t.Errorf("expected title as description fallback but got %q", violation.Description)
+	}
+	constraints := violation.Content["application/problem+json"].Schema.AllOf[1].Properties
+	if constraints["type"].Const != "/docs/validation/constraint-violation" || constraints["status"].Const != 400 {
+		t.Errorf("unexpected constraints: type=%v status=%v", constraints["type"].Const, constraints["status"].Const)
+	}
+}
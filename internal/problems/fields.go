@@ -0,0 +1,194 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package problems
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/goccy/go-yaml/ast"
+)
+
+// UnknownFieldsMode decides how keys that do not match any field of the
+// configuration format are treated when loading.
+type UnknownFieldsMode string
+
+const (
+	UnknownFieldsIgnore UnknownFieldsMode = "ignore"
+	UnknownFieldsWarn   UnknownFieldsMode = "warn"
+	UnknownFieldsError  UnknownFieldsMode = "error"
+)
+
+// LoadOptions control loading of problem configurations. The zero value
+// warns about unknown fields.
+type LoadOptions struct {
+	UnknownFields UnknownFieldsMode
+}
+
+// fieldSynonyms maps commonly mistaken keys to field names they were likely
+// meant to be, for cases too different to be caught by edit distance.
+var fieldSynonyms = map[string]string{
+	"url":     "href",
+	"uri":     "href",
+	"link":    "href",
+	"status":  "status_code",
+	"code":    "status_code",
+	"detail":  "description",
+	"details": "description",
+	"desc":    "description",
+}
+
+type unknownField struct {
+	node       ast.Node
+	path       string
+	key        string
+	suggestion string
+}
+
+func (f unknownField) message() string {
+	msg := fmt.Sprintf("unknown field `%s`", f.key)
+	if f.path != "" {
+		msg += " in " + f.path
+	}
+	if f.suggestion != "" {
+		msg += fmt.Sprintf(", did you mean `%s`?", f.suggestion)
+	}
+	return msg
+}
+
+// findUnknownFields reports mapping keys of node that do not correspond to
+// yaml tags of type t, descending into nested structs and slices of them.
+func findUnknownFields(node ast.Node, t reflect.Type, path string) []unknownField {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		mapping, ok := node.(*ast.MappingNode)
+		if !ok {
+			if value, isValue := node.(*ast.MappingValueNode); isValue {
+				mapping = &ast.MappingNode{Values: []*ast.MappingValueNode{value}}
+			} else {
+				return nil
+			}
+		}
+
+		fields := yamlFields(t)
+		var unknown []unknownField
+		for _, value := range mapping.Values {
+			key := value.Key.String()
+			field, known := fields[key]
+			if !known {
+				unknown = append(unknown, unknownField{
+					node:       value.Key,
+					path:       path,
+					key:        key,
+					suggestion: suggestField(key, fields),
+				})
+				continue
+			}
+			unknown = append(unknown, findUnknownFields(value.Value, field.Type, joinPath(path, key))...)
+		}
+		return unknown
+
+	case reflect.Slice:
+		sequence, ok := node.(*ast.SequenceNode)
+		if !ok {
+			return nil
+		}
+		var unknown []unknownField
+		for i, item := range sequence.Values {
+			unknown = append(unknown, findUnknownFields(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return unknown
+	}
+
+	return nil
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+// suggestField returns the known field closest to key, or an empty string if
+// none is close enough.
+func suggestField(key string, fields map[string]reflect.StructField) string {
+	normalized := normalizeKey(key)
+
+	if synonym, ok := fieldSynonyms[normalized]; ok {
+		if _, known := fields[synonym]; known {
+			return synonym
+		}
+	}
+
+	// Short keys are within a couple of edits of almost anything, so the
+	// allowed distance shrinks with the length of the key.
+	maxDistance := min(2, len(normalized)/2)
+
+	best := ""
+	bestDistance := maxDistance + 1
+	for name := range fields {
+		if normalizeKey(name) == normalized {
+			return name
+		}
+		if d := levenshtein(normalized, normalizeKey(name)); d < bestDistance || (d == bestDistance && name < best) {
+			best = name
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+func normalizeKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+}
+
+func levenshtein(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package problems
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/goccy/go-yaml/parser"
+)
+
+func TestFindUnknownFields(t *testing.T) {
+	content := `version: "1"
+id: "404"
+title: "Not Found"
+statuscode: 404
+sumary: "Not found"
+links:
+  - title: "Docs"
+    url: "https://example.com"
+  - title: "Spec"
+    href: "https://example.com/spec"
+    rel: "related"
+`
+	file, err := parser.ParseBytes([]byte(content), 0)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	var messages []string
+	for _, f := range findUnknownFields(file.Docs[0].Body, reflect.TypeFor[ProblemConfig](), "") {
+		messages = append(messages, f.message())
+	}
+
+	expected := []string{
+		"unknown field `statuscode`, did you mean `status_code`?",
+		"unknown field `sumary`, did you mean `summary`?",
+		"unknown field `url` in links[0], did you mean `href`?",
+		"unknown field `rel` in links[1]",
+	}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Errorf("expected %q but got %q", expected, messages)
+	}
+}
+
+func TestLoadFile_UnknownFields(t *testing.T) {
+	content := `version: "1"
+id: "404"
+title: "Not Found"
+status_code: 404
+links:
+  - title: "Docs"
+    url: "https://example.com"
+`
+	tmpFile := filepath.Join(t.TempDir(), "test.yaml")
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+
+	tests := []struct {
+		mode     UnknownFieldsMode
+		errorMsg string
+	}{
+		{UnknownFieldsIgnore, ""},
+		{UnknownFieldsWarn, ""},
+		{"", ""},
+		{UnknownFieldsError, "document 0: unknown field `url` in links[0], did you mean `href`?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			registry := NewProblemRegistry()
+			err := registry.loadFile(tmpFile, LoadOptions{UnknownFields: tt.mode})
+
+			if tt.errorMsg == "" {
+				if err != nil {
+					t.Errorf("expected no error but got: %v", err)
+				}
+				if _, exists := registry.problems["404"]; !exists {
+					t.Errorf("expected problem to be loaded")
+				}
+			} else if err == nil || err.Error() != tt.errorMsg {
+				t.Errorf("expected error %q but got %v", tt.errorMsg, err)
+			}
+		})
+	}
+}
+
+func TestLint_UnknownFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := `version: "1"
+id: "404"
+title: "Not Found"
+status_code: 404
+summary: "Not found"
+description: "Resource not found"
+descripton: "Typo"
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "test.yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	tests := []struct {
+		mode     UnknownFieldsMode
+		expected []Diagnostic
+	}{
+		{UnknownFieldsIgnore, nil},
+		{UnknownFieldsWarn, []Diagnostic{{Line: 7, Column: 1, Severity: SeverityWarning, Rule: "unknown-field",
+			Message: "document 0: unknown field `descripton`, did you mean `description`?"}}},
+		{UnknownFieldsError, []Diagnostic{{Line: 7, Column: 1, Severity: SeverityError, Rule: "unknown-field",
+			Message: "document 0: unknown field `descripton`, did you mean `description`?"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			result, err := Lint(tmpDir, LoadOptions{UnknownFields: tt.mode})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result.Diagnostics) != len(tt.expected) {
+				t.Fatalf("expected %d diagnostics but got %+v", len(tt.expected), result.Diagnostics)
+			}
+			for i, e := range tt.expected {
+				d := result.Diagnostics[i]
+				d.File = ""
+				if d != e {
+					t.Errorf("expected %+v but got %+v", e, d)
+				}
+			}
+		})
+	}
+}
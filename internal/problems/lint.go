@@ -0,0 +1,383 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package problems
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a single finding reported by Lint. Line and column are
+// 1-based and point at the offending value, or at the start of the document
+// when the value is missing.
+type Diagnostic struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Column   int      `json:"column"`
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	Message  string   `json:"message"`
+}
+
+type LintResult struct {
+	Files       []string     `json:"files"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// Count returns the number of diagnostics of the given severity.
+func (r *LintResult) Count(severity Severity) int {
+	count := 0
+	for _, d := range r.Diagnostics {
+		if d.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+type definition struct {
+	file string
+	line int
+}
+
+// reference is a mention of a problem ID by another problem, like an alias
+// or a replacement, which can only be checked once all files are read.
+type reference struct {
+	target string
+	id     string
+	file   string
+	line   int
+	column int
+}
+
+type linter struct {
+	opts         LoadOptions
+	result       LintResult
+	defined      map[string]definition
+	aliases      []reference
+	replacements []reference
+	removed      map[string]definition
+	tombstones   []reference
+}
+
+// Lint checks all files that LoadFromDirectory would load. Unlike loading,
+// it does not stop at the first invalid document, and reports every finding
+// with its position. Findings with SeverityError are the ones that make
+// loading fail with the same options. The returned error is reserved for an
+// unreadable directory.
+func Lint(dirPath string, opts LoadOptions) (*LintResult, error) {
+	if _, err := os.Stat(dirPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("problems directory does not exist: %s", dirPath)
+	}
+
+	l := &linter{
+		opts:    opts,
+		result:  LintResult{Files: []string{}, Diagnostics: []Diagnostic{}},
+		defined: map[string]definition{},
+		removed: map[string]definition{},
+	}
+
+	err := walkProblemFiles(dirPath, func(path string, err error) error {
+		l.result.Files = append(l.result.Files, path)
+		if err != nil {
+			l.report(path, 0, 0, SeverityError, "access", fmt.Sprintf("access error: %s", err))
+			return nil
+		}
+		l.lintFile(path)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory: %w", err)
+	}
+
+	l.lintAliases()
+	l.lintReplacements()
+	l.lintTombstones()
+
+	return &l.result, nil
+}
+
+// lintReplacements reports deprecated problems replaced by unknown ones.
+func (l *linter) lintReplacements() {
+	for _, r := range l.replacements {
+		if _, exists := l.defined[r.target]; !exists {
+			l.report(r.file, r.line, r.column, SeverityError, "unknown-replacement",
+				fmt.Sprintf("problem %s is replaced by unknown problem: %s", r.id, r.target))
+		}
+	}
+}
+
+// lintAliases reports aliases colliding with problem IDs or with aliases of
+// other problems, which is only known once all files are read.
+func (l *linter) lintAliases() {
+	seen := map[string]reference{}
+	for _, a := range l.aliases {
+		if first, exists := l.defined[a.target]; exists {
+			l.report(a.file, a.line, a.column, SeverityError, "alias-collision",
+				fmt.Sprintf("alias %s of problem %s collides with problem ID (defined in %s:%d)", a.target, a.id, first.file, first.line))
+			continue
+		}
+		if first, exists := seen[a.target]; exists && first.id != a.id {
+			l.report(a.file, a.line, a.column, SeverityError, "alias-collision",
+				fmt.Sprintf("alias %s of problem %s is already an alias of problem %s (%s:%d)", a.target, a.id, first.id, first.file, first.line))
+			continue
+		}
+		seen[a.target] = a
+	}
+}
+
+// lintTombstones reports tombstones of problems that are still defined,
+// either by ID or by alias.
+func (l *linter) lintTombstones() {
+	aliases := map[string]reference{}
+	for _, a := range l.aliases {
+		if _, exists := aliases[a.target]; !exists {
+			aliases[a.target] = a
+		}
+	}
+	for _, t := range l.tombstones {
+		if first, exists := l.defined[t.target]; exists {
+			l.report(t.file, t.line, t.column, SeverityError, "tombstone-collision",
+				fmt.Sprintf("tombstone %s collides with problem ID (defined in %s:%d)", t.target, first.file, first.line))
+			continue
+		}
+		if alias, exists := aliases[t.target]; exists {
+			l.report(t.file, t.line, t.column, SeverityError, "tombstone-collision",
+				fmt.Sprintf("tombstone %s collides with an alias of problem %s (%s:%d)", t.target, alias.id, alias.file, alias.line))
+		}
+	}
+}
+
+func (l *linter) report(file string, line int, column int, severity Severity, rule string, message string) {
+	l.result.Diagnostics = append(l.result.Diagnostics, Diagnostic{
+		File:     file,
+		Line:     line,
+		Column:   column,
+		Severity: severity,
+		Rule:     rule,
+		Message:  message,
+	})
+}
+
+func (l *linter) reportAt(file string, node ast.Node, severity Severity, rule string, message string) {
+	line, column := nodePosition(node)
+	l.report(file, line, column, severity, rule, message)
+}
+
+func (l *linter) lintFile(path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		l.report(path, 0, 0, SeverityError, "access", fmt.Sprintf("failed to open file: %s", err))
+		return
+	}
+
+	file, err := parser.ParseBytes(data, 0)
+	if err != nil {
+		line, column := errorPosition(err)
+		l.report(path, line, column, SeverityError, "syntax", fmt.Sprintf("failed to parse YAML: %s", errorMessage(err)))
+		return
+	}
+
+	documents := 0
+	for _, doc := range file.Docs {
+		if doc.Body == nil {
+			continue
+		}
+		l.lintDocument(path, documents, doc.Body)
+		documents++
+	}
+
+	if documents == 0 {
+		l.report(path, 1, 1, SeverityError, "empty-file", "no valid YAML documents found in file")
+	}
+}
+
+func (l *linter) lintDocument(path string, docIndex int, body ast.Node) {
+	if isTombstone(body) {
+		l.lintTombstone(path, docIndex, body)
+		return
+	}
+
+	var problem ProblemConfig
+	if err := yaml.NodeToValue(body, &problem); err != nil {
+		line, column := errorPosition(err)
+		if line == 0 {
+			line, column = nodePosition(body)
+		}
+		l.report(path, line, column, SeverityError, "invalid-value", fmt.Sprintf("document %d: %s", docIndex, errorMessage(err)))
+		return
+	}
+
+	l.lintUnknownFields(path, docIndex, body, reflect.TypeFor[ProblemConfig]())
+
+	for _, v := range checkProblemConfig(&problem) {
+		l.reportAt(path, fieldNode(body, v.field), SeverityError, "invalid-field", fmt.Sprintf("document %d: %s", docIndex, v.err))
+	}
+
+	if problem.ID != "" {
+		line, _ := nodePosition(fieldNode(body, "id"))
+		if first, exists := l.defined[problem.ID]; exists {
+			l.reportAt(path, fieldNode(body, "id"), SeverityError, "duplicate-id",
+				fmt.Sprintf("document %d: duplicate problem ID found: %s (first defined in %s:%d)", docIndex, problem.ID, first.file, first.line))
+		} else {
+			l.defined[problem.ID] = definition{file: path, line: line}
+		}
+	}
+
+	if problem.ID != "" {
+		for i, alias := range problem.Aliases {
+			if alias == "" || alias == problem.ID {
+				continue
+			}
+			line, column := nodePosition(fieldNode(body, fmt.Sprintf("aliases[%d]", i)))
+			l.aliases = append(l.aliases, reference{target: alias, id: problem.ID, file: path, line: line, column: column})
+		}
+		if problem.Deprecated && problem.ReplacedBy != "" && problem.ReplacedBy != problem.ID {
+			line, column := nodePosition(fieldNode(body, "replaced_by"))
+			l.replacements = append(l.replacements, reference{target: problem.ReplacedBy, id: problem.ID, file: path, line: line, column: column})
+		}
+	}
+
+	if problem.StatusCode >= 100 && problem.StatusCode < 400 {
+		l.reportAt(path, fieldNode(body, "status_code"), SeverityWarning, "status-code-range",
+			fmt.Sprintf("document %d: status_code %d is not a client or server error", docIndex, problem.StatusCode))
+	}
+	if problem.Summary == "" {
+		l.reportAt(path, fieldNode(body, "summary"), SeverityWarning, "missing-summary",
+			fmt.Sprintf("document %d: summary is empty, it is shown on the index page", docIndex))
+	}
+	if problem.Description == "" {
+		l.reportAt(path, fieldNode(body, "description"), SeverityWarning, "missing-description",
+			fmt.Sprintf("document %d: description is empty", docIndex))
+	}
+	for i, link := range problem.Links {
+		if link.Href == "" {
+			l.reportAt(path, fieldNode(body, fmt.Sprintf("links[%d]", i)), SeverityWarning, "link-missing-href",
+				fmt.Sprintf("document %d: links[%d] has no href", docIndex, i))
+		}
+		if link.Title == "" {
+			l.reportAt(path, fieldNode(body, fmt.Sprintf("links[%d]", i)), SeverityWarning, "link-missing-title",
+				fmt.Sprintf("document %d: links[%d] has no title", docIndex, i))
+		}
+	}
+}
+
+func (l *linter) lintTombstone(path string, docIndex int, body ast.Node) {
+	var tombstone Tombstone
+	if err := yaml.NodeToValue(body, &tombstone); err != nil {
+		line, column := errorPosition(err)
+		if line == 0 {
+			line, column = nodePosition(body)
+		}
+		l.report(path, line, column, SeverityError, "invalid-value", fmt.Sprintf("document %d: %s", docIndex, errorMessage(err)))
+		return
+	}
+
+	l.lintUnknownFields(path, docIndex, body, reflect.TypeFor[Tombstone]())
+
+	for _, v := range checkTombstone(&tombstone) {
+		l.reportAt(path, fieldNode(body, v.field), SeverityError, "invalid-field", fmt.Sprintf("document %d: %s", docIndex, v.err))
+	}
+
+	if tombstone.ID == "" {
+		return
+	}
+
+	line, column := nodePosition(fieldNode(body, "id"))
+	if first, exists := l.removed[tombstone.ID]; exists {
+		l.report(path, line, column, SeverityError, "duplicate-id",
+			fmt.Sprintf("document %d: duplicate tombstone ID found: %s (first defined in %s:%d)", docIndex, tombstone.ID, first.file, first.line))
+	} else {
+		l.removed[tombstone.ID] = definition{file: path, line: line}
+		l.tombstones = append(l.tombstones, reference{target: tombstone.ID, id: tombstone.ID, file: path, line: line, column: column})
+	}
+
+	if tombstone.ReplacedBy != "" && tombstone.ReplacedBy != tombstone.ID {
+		line, column := nodePosition(fieldNode(body, "replaced_by"))
+		l.replacements = append(l.replacements, reference{target: tombstone.ReplacedBy, id: tombstone.ID, file: path, line: line, column: column})
+	}
+}
+
+// lintUnknownFields reports keys not matching fields of t, as warnings or
+// as errors depending on the unknown fields mode.
+func (l *linter) lintUnknownFields(path string, docIndex int, body ast.Node, t reflect.Type) {
+	if l.opts.UnknownFields == UnknownFieldsIgnore {
+		return
+	}
+	severity := SeverityWarning
+	if l.opts.UnknownFields == UnknownFieldsError {
+		severity = SeverityError
+	}
+	for _, f := range findUnknownFields(body, t, "") {
+		l.reportAt(path, f.node, severity, "unknown-field", fmt.Sprintf("document %d: %s", docIndex, f.message()))
+	}
+}
+
+// fieldNode returns the node at a YAML path relative to the document body
+// (like "links[0].href"), falling back to the body itself when missing.
+func fieldNode(body ast.Node, field string) ast.Node {
+	path, err := yaml.PathString("$." + field)
+	if err != nil {
+		return body
+	}
+	node, err := path.FilterNode(body)
+	if err != nil || node == nil {
+		return body
+	}
+	return node
+}
+
+func nodePosition(node ast.Node) (int, int) {
+	// Tokens of mappings point at the first colon, report the first key instead.
+	if mapping, ok := node.(*ast.MappingNode); ok && len(mapping.Values) > 0 {
+		node = mapping.Values[0].Key
+	}
+	if node == nil || node.GetToken() == nil || node.GetToken().Position == nil {
+		return 0, 0
+	}
+	position := node.GetToken().Position
+	return position.Line, position.Column
+}
+
+func errorPosition(err error) (int, int) {
+	var yamlErr yaml.Error
+	if !errors.As(err, &yamlErr) || yamlErr.GetToken() == nil || yamlErr.GetToken().Position == nil {
+		return 0, 0
+	}
+	position := yamlErr.GetToken().Position
+	return position.Line, position.Column
+}
+
+// errorMessage strips source excerpts that goccy/go-yaml includes in errors.
+func errorMessage(err error) string {
+	var yamlErr yaml.Error
+	if errors.As(err, &yamlErr) {
+		return yamlErr.GetMessage()
+	}
+	return err.Error()
+}
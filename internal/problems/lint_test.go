@@ -0,0 +1,230 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package problems
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"a-valid.yaml": `version: "1"
+id: "404"
+title: "Not Found"
+status_code: 404
+summary: "Not found"
+description: "Resource not found"`,
+		"invalid.yaml": `version: "1"
+id: "500"
+title: "Internal Server Error"
+status_code: 500
+summary: "Server error"
+description: "Internal error"
+---
+version: "3"
+id: "404"
+status_code: 404
+summary: "Duplicate"
+description: "Duplicate"
+links:
+  - title: "Docs"`,
+		"syntax.yaml": "version: \"1\"\nid: [\n",
+		"empty.yaml":  "",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	result, err := Lint(tmpDir, LoadOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Files) != 4 {
+		t.Errorf("expected 4 files but got %d", len(result.Files))
+	}
+
+	expected := []struct {
+		file     string
+		line     int
+		column   int
+		severity Severity
+		rule     string
+	}{
+		{"empty.yaml", 1, 1, SeverityError, "empty-file"},
+		{"invalid.yaml", 8, 10, SeverityError, "invalid-field"},
+		{"invalid.yaml", 8, 1, SeverityError, "invalid-field"},
+		{"invalid.yaml", 9, 5, SeverityError, "duplicate-id"},
+		{"invalid.yaml", 14, 5, SeverityWarning, "link-missing-href"},
+		{"syntax.yaml", 2, 5, SeverityError, "syntax"},
+	}
+
+	if len(result.Diagnostics) != len(expected) {
+		for _, d := range result.Diagnostics {
+			t.Logf("%+v", d)
+		}
+		t.Fatalf("expected %d diagnostics but got %d", len(expected), len(result.Diagnostics))
+	}
+
+	for i, e := range expected {
+		d := result.Diagnostics[i]
+		if filepath.Base(d.File) != e.file || d.Line != e.line || d.Column != e.column || d.Severity != e.severity || d.Rule != e.rule {
+			t.Errorf("expected %+v but got %+v", e, d)
+		}
+	}
+
+	if result.Count(SeverityError) != 5 || result.Count(SeverityWarning) != 1 {
+		t.Errorf("unexpected counts: %d errors, %d warnings", result.Count(SeverityError), result.Count(SeverityWarning))
+	}
+}
+
+func TestLint_NonExistentDirectory(t *testing.T) {
+	if _, err := Lint("/non/existent/path", LoadOptions{}); err == nil {
+		t.Errorf("expected error for non-existent directory")
+	}
+}
+
+func TestLint_Examples(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := `version: "1"
+id: "404"
+title: "Not Found"
+status_code: 404
+summary: "Not found"
+description: "Resource not found"
+examples:
+  - title: "Valid"
+    body: '{"status": 404}'
+  - title: "Malformed"
+    body: |
+      {"status": 404
+  - title: "Wrong status"
+    body: '{"status": 410}'
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "test.yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := Lint(tmpDir, LoadOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Diagnostic{
+		{Line: 11, Column: 11, Severity: SeverityError, Rule: "invalid-field",
+			Message: "document 0: examples[1] body is not valid JSON: unexpected end of JSON input"},
+		{Line: 14, Column: 11, Severity: SeverityError, Rule: "invalid-field",
+			Message: "document 0: examples[2] body status 410 differs from status_code 404"},
+	}
+	if len(result.Diagnostics) != len(expected) {
+		t.Fatalf("expected %d diagnostics but got %+v", len(expected), result.Diagnostics)
+	}
+	for i, e := range expected {
+		d := result.Diagnostics[i]
+		d.File = ""
+		if d != e {
+			t.Errorf("expected %+v but got %+v", e, d)
+		}
+	}
+}
+
+func TestLint_Aliases(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"a.yaml": `version: "1"
+id: "users/not-found"
+title: "Not Found"
+status_code: 404
+summary: "Not found"
+description: "User not found"
+aliases:
+  - "missing-user"
+  - "500"`,
+		"b.yaml": `version: "1"
+id: "500"
+title: "Internal Server Error"
+status_code: 500
+summary: "Server error"
+description: "Internal error"
+aliases: ["missing-user"]`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	result, err := Lint(tmpDir, LoadOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		file    string
+		line    int
+		message string
+	}{
+		{"a.yaml", 9, "alias 500 of problem users/not-found collides with problem ID (defined in %s:2)"},
+		{"b.yaml", 7, "alias missing-user of problem 500 is already an alias of problem users/not-found (%s:8)"},
+	}
+	if len(result.Diagnostics) != len(expected) {
+		t.Fatalf("expected %d diagnostics but got %+v", len(expected), result.Diagnostics)
+	}
+	for i, e := range expected {
+		d := result.Diagnostics[i]
+		otherFile := filepath.Join(tmpDir, "b.yaml")
+		if e.file == "b.yaml" {
+			otherFile = filepath.Join(tmpDir, "a.yaml")
+		}
+		message := fmt.Sprintf(e.message, otherFile)
+		if filepath.Base(d.File) != e.file || d.Line != e.line || d.Rule != "alias-collision" || d.Message != message {
+			t.Errorf("expected %s:%d %q but got %+v", e.file, e.line, message, d)
+		}
+	}
+}
+
+func TestLint_Replacements(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := `version: "1"
+id: "users/gone"
+title: "Gone"
+status_code: 410
+summary: "Gone"
+description: "User was removed"
+deprecated: true
+replaced_by: "users/missing"`
+	if err := os.WriteFile(filepath.Join(tmpDir, "gone.yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := Lint(tmpDir, LoadOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic but got %+v", result.Diagnostics)
+	}
+	d := result.Diagnostics[0]
+	if d.Line != 8 || d.Rule != "unknown-replacement" || d.Message != "problem users/gone is replaced by unknown problem: users/missing" {
+		t.Errorf("unexpected diagnostic: %+v", d)
+	}
+}
@@ -15,42 +15,114 @@
 package problems
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
 	"github.com/rs/zerolog/log"
 )
 
 type Link struct {
-	Title string `yaml:"title"`
-	Href  string `yaml:"href"`
+	Title string `yaml:"title" json:"title"`
+	Href  string `yaml:"href" json:"href"`
+}
+
+// Extension describes an extension member of problem details (RFC 9457,
+// section 3.2), i.e. a member that clients may parse besides the standard
+// ones. Members of nested objects are named by their path, like
+// "errors[].field".
+type Extension struct {
+	Name        string `yaml:"name" json:"name"`
+	Type        string `yaml:"type" json:"type"`
+	Required    bool   `yaml:"required" json:"required"`
+	Description string `yaml:"description" json:"description"`
+	Example     any    `yaml:"example" json:"example,omitempty"`
+}
+
+// Example is a sample exchange of a problem. The body is a JSON document kept
+// as written, so that it is shown with its original formatting.
+type Example struct {
+	Title   string            `yaml:"title" json:"title"`
+	Request string            `yaml:"request" json:"request,omitempty"`
+	Headers map[string]string `yaml:"headers" json:"headers,omitempty"`
+	Body    string            `yaml:"body" json:"body"`
 }
 
 type ProblemConfig struct {
-	Version     string `yaml:"version"`
-	ID          string `yaml:"id"`
-	Name        string `yaml:"name"`
-	Title       string `yaml:"title"`
-	StatusCode  int    `yaml:"status_code"`
-	Summary     string `yaml:"summary"`
-	Description string `yaml:"description"`
-	Links       []Link `yaml:"links"`
+	Version     string      `yaml:"version" json:"version"`
+	ID          string      `yaml:"id" json:"id"`
+	Aliases     []string    `yaml:"aliases" json:"aliases"`
+	Name        string      `yaml:"name" json:"name"`
+	Title       string      `yaml:"title" json:"title"`
+	StatusCode  int         `yaml:"status_code" json:"status_code"`
+	Summary     string      `yaml:"summary" json:"summary"`
+	Description string      `yaml:"description" json:"description"`
+	Category    string      `yaml:"category" json:"category"`
+	Tags        []string    `yaml:"tags" json:"tags"`
+	Links       []Link      `yaml:"links" json:"links"`
+	Extensions  []Extension `yaml:"extensions" json:"extensions"`
+	Examples    []Example   `yaml:"examples" json:"examples"`
+
+	Deprecated      bool   `yaml:"deprecated" json:"deprecated"`
+	DeprecatedSince string `yaml:"deprecated_since" json:"deprecated_since,omitempty"`
+	Sunset          string `yaml:"sunset" json:"sunset,omitempty"`
+	ReplacedBy      string `yaml:"replaced_by" json:"replaced_by,omitempty"`
+}
+
+// ParseDate parses dates of deprecation fields, given either as a date like
+// "2025-06-30" (midnight UTC) or as an RFC 3339 timestamp.
+func ParseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
 }
 
-type ProblemRegistry struct {
-	problems map[string]*ProblemConfig
+// extensionTypes are the JSON types an extension member may have.
+var extensionTypes = []string{"string", "number", "integer", "boolean", "object", "array"}
+
+// standardMembers are the members defined by RFC 9457, which extensions must
+// not redefine.
+var standardMembers = []string{"type", "title", "status", "detail", "instance"}
+
+// tagPattern restricts tags to URL-friendly slugs, as they are used as path
+// segments of tag pages.
+var tagPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// LoadError aggregates all failures encountered while loading a directory.
+type LoadError struct {
+	Failures []error
 }
 
-func NewProblemRegistry() *ProblemRegistry {
-	return &ProblemRegistry{
-		problems: make(map[string]*ProblemConfig),
+func (e *LoadError) Error() string {
+	errorMsg := "failed to load error configurations:"
+	for _, err := range e.Failures {
+		errorMsg += fmt.Sprintf("\n  - %s", err.Error())
 	}
+	return errorMsg
 }
 
 func LoadFromDirectory(dirPath string) (*ProblemRegistry, error) {
+	return loadFromDirectory(dirPath, LoadOptions{}, 1)
+}
+
+// LoadFromDirectoryWithOptions is LoadFromDirectory with control over how
+// unknown fields are treated.
+func LoadFromDirectoryWithOptions(dirPath string, opts LoadOptions) (*ProblemRegistry, error) {
+	return loadFromDirectory(dirPath, opts, 1)
+}
+
+func loadFromDirectory(dirPath string, opts LoadOptions, generation uint64) (*ProblemRegistry, error) {
 	registry := NewProblemRegistry()
 
 	if _, err := os.Stat(dirPath); os.IsNotExist(err) {
@@ -59,22 +131,13 @@ func LoadFromDirectory(dirPath string) (*ProblemRegistry, error) {
 
 	var loadFailures []error
 
-	err := filepath.WalkDir(dirPath, func(path string, d fs.DirEntry, err error) error {
+	err := walkProblemFiles(dirPath, func(path string, err error) error {
 		if err != nil {
 			loadFailures = append(loadFailures, fmt.Errorf("access error at %s: %w", path, err))
 			return nil
 		}
 
-		if d.IsDir() {
-			return nil
-		}
-
-		ext := filepath.Ext(d.Name())
-		if ext != ".yaml" && ext != ".yml" {
-			return nil
-		}
-
-		if err := registry.loadFile(path); err != nil {
+		if err := registry.loadFile(path, opts); err != nil {
 			loadFailures = append(loadFailures, fmt.Errorf("failed to load %s: %w", path, err))
 		}
 
@@ -85,57 +148,292 @@ func LoadFromDirectory(dirPath string) (*ProblemRegistry, error) {
 		return nil, fmt.Errorf("failed to walk directory: %w", err)
 	}
 
+	if len(loadFailures) == 0 {
+		loadFailures = append(registry.indexAliases(), registry.checkReplacements()...)
+		loadFailures = append(loadFailures, registry.checkTombstones()...)
+	}
+
 	if len(loadFailures) > 0 {
-		errorMsg := "failed to load error configurations:"
-		for _, err := range loadFailures {
-			errorMsg += fmt.Sprintf("\n  - %s", err.Error())
-		}
-		return nil, fmt.Errorf("%s", errorMsg)
+		return nil, &LoadError{Failures: loadFailures}
 	}
 
-	log.Info().Int("count", len(registry.problems)).Msg("loaded problem configurations")
+	registry.seal(generation)
+
+	log.Info().Int("count", registry.Len()).Uint64("generation", generation).Msg("loaded problem configurations")
 	return registry, nil
 }
 
+// walkProblemFiles calls fn for every YAML file found under dirPath. The
+// directory itself and its subdirectories may be symlinks, and hidden entries
+// are skipped, which covers the "..data" and "..<timestamp>" entries
+// Kubernetes creates for ConfigMap volumes, where nested directories are
+// symlinks into "..data" as well. Access errors are passed to fn instead of a
+// path.
+func walkProblemFiles(dirPath string, fn func(path string, err error) error) error {
+	root, err := filepath.EvalSymlinks(dirPath)
+	if err != nil {
+		return err
+	}
+
+	return walkProblemDir(root, root, map[string]bool{}, fn)
+}
+
+// walkProblemDir walks the resolved directory dir, reporting paths under
+// base, which is either dir itself or a symlink to it. Every directory is
+// walked at most once, so that symlinks cannot form cycles.
+func walkProblemDir(base string, dir string, visited map[string]bool, fn func(path string, err error) error) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		rel, _ := filepath.Rel(dir, path)
+		linked := filepath.Join(base, rel)
+		if err != nil {
+			return fn(linked, err)
+		}
+
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			if visited[path] {
+				return filepath.SkipDir
+			}
+			visited[path] = true
+			return nil
+		}
+
+		if d.Type()&fs.ModeSymlink != 0 {
+			if target, err := filepath.EvalSymlinks(path); err == nil {
+				if info, err := os.Stat(target); err == nil && info.IsDir() {
+					return walkProblemDir(linked, target, visited, fn)
+				}
+			}
+		}
+
+		ext := filepath.Ext(d.Name())
+		if ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+
+		return fn(linked, nil)
+	})
+}
+
+// ValidateProblemConfig checks a problem configuration the same way as it is
+// checked when loading files, filling in defaults of optional fields.
+func ValidateProblemConfig(config *ProblemConfig) error {
+	return validateProblemConfig(config)
+}
+
 func validateProblemConfig(config *ProblemConfig) error {
-	if config.Version != "1" {
-		return fmt.Errorf("problem configuration version must be \"1\", got: %s", config.Version)
+	if violations := checkProblemConfig(config); len(violations) > 0 {
+		return violations[0].err
+	}
+	if config.Name == "" {
+		config.Name = config.Title
 	}
+	return nil
+}
+
+// violation is a validation failure of a single field, identified by its
+// YAML path relative to the document root.
+type violation struct {
+	field string
+	err   error
+}
+
+// checkProblemConfig returns all validation failures of a problem
+// configuration, in order of significance. Checks depend on the schema
+// version, where version "2" adds extension members to version "1".
+func checkProblemConfig(config *ProblemConfig) []violation {
+	var violations []violation
+	switch config.Version {
+	case "1":
+		violations = checkCommonFields(config)
+		if len(config.Extensions) > 0 {
+			violations = append(violations, violation{"extensions", fmt.Errorf("problem configuration extensions require version \"2\"")})
+		}
+		violations = append(violations, checkExamples(config)...)
+	case "2":
+		violations = append(checkCommonFields(config), checkExtensions(config.Extensions)...)
+		violations = append(violations, checkExamples(config)...)
+	default:
+		violations = append(violations, violation{"version", fmt.Errorf("problem configuration version must be \"1\" or \"2\", got: %s", config.Version)})
+		violations = append(violations, checkCommonFields(config)...)
+	}
+	return violations
+}
+
+// checkCommonFields checks fields shared by all schema versions.
+func checkCommonFields(config *ProblemConfig) []violation {
+	var violations []violation
 	if config.ID == "" {
-		return fmt.Errorf("problem configuration missing required field: id")
+		violations = append(violations, violation{"id", fmt.Errorf("problem configuration missing required field: id")})
 	}
 	if config.Title == "" {
-		return fmt.Errorf("problem configuration missing required field: title")
+		violations = append(violations, violation{"title", fmt.Errorf("problem configuration missing required field: title")})
 	}
 	if config.StatusCode == 0 {
-		return fmt.Errorf("problem configuration missing required field: status_code")
+		violations = append(violations, violation{"status_code", fmt.Errorf("problem configuration missing required field: status_code")})
+	} else if config.StatusCode < 100 || config.StatusCode > 599 {
+		violations = append(violations, violation{"status_code", fmt.Errorf("status_code must be between 100 and 599, got: %d", config.StatusCode)})
 	}
-	if config.Name == "" {
-		config.Name = config.Title
+	violations = append(violations, checkDeprecation(config)...)
+	for i, tag := range config.Tags {
+		field := fmt.Sprintf("tags[%d]", i)
+		switch {
+		case tag == "":
+			violations = append(violations, violation{field, fmt.Errorf("%s must not be empty", field)})
+		case !tagPattern.MatchString(tag):
+			violations = append(violations, violation{field, fmt.Errorf("%s must consist of lowercase letters, digits and hyphens, got: %s", field, tag)})
+		case slices.Contains(config.Tags[:i], tag):
+			violations = append(violations, violation{field, fmt.Errorf("%s duplicates tag: %s", field, tag)})
+		}
 	}
-	return nil
+	for i, alias := range config.Aliases {
+		field := fmt.Sprintf("aliases[%d]", i)
+		switch {
+		case alias == "":
+			violations = append(violations, violation{field, fmt.Errorf("%s must not be empty", field)})
+		case alias == config.ID:
+			violations = append(violations, violation{field, fmt.Errorf("%s equals the problem ID: %s", field, alias)})
+		case slices.Contains(config.Aliases[:i], alias):
+			violations = append(violations, violation{field, fmt.Errorf("%s duplicates alias: %s", field, alias)})
+		}
+	}
+	return violations
+}
+
+func checkExtensions(extensions []Extension) []violation {
+	var violations []violation
+	seen := map[string]bool{}
+	for i, ext := range extensions {
+		field := fmt.Sprintf("extensions[%d]", i)
+		switch {
+		case ext.Name == "":
+			violations = append(violations, violation{field, fmt.Errorf("%s missing required field: name", field)})
+		case slices.Contains(standardMembers, ext.Name):
+			violations = append(violations, violation{field + ".name", fmt.Errorf("%s redefines standard member: %s", field, ext.Name)})
+		case seen[ext.Name]:
+			violations = append(violations, violation{field + ".name", fmt.Errorf("%s duplicates extension: %s", field, ext.Name)})
+		}
+		seen[ext.Name] = true
+
+		if ext.Type == "" {
+			violations = append(violations, violation{field, fmt.Errorf("%s missing required field: type", field)})
+		} else if !slices.Contains(extensionTypes, ext.Type) {
+			violations = append(violations, violation{field + ".type", fmt.Errorf("%s type must be one of %s, got: %s", field, strings.Join(extensionTypes, ", "), ext.Type)})
+		}
+	}
+	return violations
+}
+
+// checkDeprecation requires deprecation details to come with the deprecated
+// flag, so that setting a sunset date alone does not hide a problem. Whether
+// replaced_by refers to an existing problem is checked once all files are
+// loaded.
+func checkDeprecation(config *ProblemConfig) []violation {
+	var violations []violation
+	fields := []struct {
+		name  string
+		value string
+		date  bool
+	}{
+		{"deprecated_since", config.DeprecatedSince, true},
+		{"sunset", config.Sunset, true},
+		{"replaced_by", config.ReplacedBy, false},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		if !config.Deprecated {
+			violations = append(violations, violation{f.name, fmt.Errorf("problem configuration %s requires deprecated to be true", f.name)})
+			continue
+		}
+		if f.date {
+			if _, err := ParseDate(f.value); err != nil {
+				violations = append(violations, violation{f.name, fmt.Errorf("problem configuration %s must be a date like 2025-06-30 or an RFC 3339 timestamp, got: %s", f.name, f.value)})
+			}
+		}
+	}
+	if config.ReplacedBy != "" && config.ReplacedBy == config.ID {
+		violations = append(violations, violation{"replaced_by", fmt.Errorf("problem configuration replaced_by refers to the problem itself: %s", config.ReplacedBy)})
+	}
+	return violations
 }
 
-func (r *ProblemRegistry) loadFile(filePath string) error {
-	file, err := os.Open(filePath)
+// checkExamples requires example bodies to be valid JSON, and their status
+// member, if any, to match the status code of the problem.
+func checkExamples(config *ProblemConfig) []violation {
+	var violations []violation
+	for i, example := range config.Examples {
+		field := fmt.Sprintf("examples[%d]", i)
+		if strings.TrimSpace(example.Body) == "" {
+			violations = append(violations, violation{field, fmt.Errorf("%s missing required field: body", field)})
+			continue
+		}
+
+		var body any
+		if err := json.Unmarshal([]byte(example.Body), &body); err != nil {
+			violations = append(violations, violation{field + ".body", fmt.Errorf("%s body is not valid JSON: %w", field, err)})
+			continue
+		}
+
+		object, ok := body.(map[string]any)
+		if !ok || config.StatusCode == 0 {
+			continue
+		}
+		if status, exists := object["status"]; exists && status != float64(config.StatusCode) {
+			formatted, _ := json.Marshal(status)
+			violations = append(violations, violation{field + ".body", fmt.Errorf("%s body status %s differs from status_code %d", field, formatted, config.StatusCode)})
+		}
+	}
+	return violations
+}
+
+func (r *ProblemRegistry) loadFile(filePath string, opts LoadOptions) error {
+	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to open file: %w", err)
 	}
-	defer file.Close()
 
-	decoder := yaml.NewDecoder(file)
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	file, err := parser.ParseBytes(data, 0)
+	if err != nil {
+		return fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
 	docIndex := 0
 
-	for {
-		var problem ProblemConfig
-		err := decoder.Decode(&problem)
-		if err != nil {
-			if err.Error() == "EOF" {
-				break
+	for _, doc := range file.Docs {
+		if doc.Body == nil {
+			continue
+		}
+
+		if isTombstone(doc.Body) {
+			if err := r.loadTombstone(doc.Body, filePath, info.ModTime(), docIndex, opts); err != nil {
+				return err
 			}
+			docIndex++
+			continue
+		}
+
+		var problem ProblemConfig
+		if err := yaml.NodeToValue(doc.Body, &problem); err != nil {
 			return fmt.Errorf("failed to parse YAML document %d: %w", docIndex, err)
 		}
 
+		if err := checkUnknownFields(doc.Body, reflect.TypeFor[ProblemConfig](), filePath, docIndex, opts); err != nil {
+			return err
+		}
+
 		if err := validateProblemConfig(&problem); err != nil {
 			return fmt.Errorf("document %d: %w", docIndex, err)
 		}
@@ -145,6 +443,7 @@ func (r *ProblemRegistry) loadFile(filePath string) error {
 		}
 
 		r.problems[problem.ID] = &problem
+		r.sources[problem.ID] = problemSource{path: filePath, modTime: info.ModTime()}
 		log.Debug().Str("id", problem.ID).Str("file", filePath).Int("document", docIndex).Msg("loaded problem configuration")
 		docIndex++
 	}
@@ -156,11 +455,19 @@ func (r *ProblemRegistry) loadFile(filePath string) error {
 	return nil
 }
 
-func (r *ProblemRegistry) Get(id string) (*ProblemConfig, bool) {
-	errConfig, exists := r.problems[id]
-	return errConfig, exists
-}
+// checkUnknownFields applies the unknown fields mode to a single document,
+// failing on the first unknown field in UnknownFieldsError mode.
+func checkUnknownFields(body ast.Node, t reflect.Type, filePath string, docIndex int, opts LoadOptions) error {
+	if opts.UnknownFields == UnknownFieldsIgnore {
+		return nil
+	}
 
-func (r *ProblemRegistry) GetAll() map[string]*ProblemConfig {
-	return r.problems
+	for _, f := range findUnknownFields(body, t, "") {
+		if opts.UnknownFields == UnknownFieldsError {
+			return fmt.Errorf("document %d: %s", docIndex, f.message())
+		}
+		line, column := nodePosition(f.node)
+		log.Warn().Str("file", filePath).Int("document", docIndex).Int("line", line).Int("column", column).Msg(f.message())
+	}
+	return nil
 }
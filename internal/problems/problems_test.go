@@ -15,9 +15,12 @@
 package problems
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestValidateProblemConfig(t *testing.T) {
@@ -48,18 +51,240 @@ func TestValidateProblemConfig(t *testing.T) {
 				StatusCode: 404,
 			},
 			expectError: true,
-			errorMsg:    "problem configuration version must be \"1\", got: ",
+			errorMsg:    "problem configuration version must be \"1\" or \"2\", got: ",
 		},
 		{
 			name: "wrong version",
+			config: ProblemConfig{
+				Version:    "3",
+				ID:         "404",
+				Title:      "Not Found",
+				StatusCode: 404,
+			},
+			expectError: true,
+			errorMsg:    "problem configuration version must be \"1\" or \"2\", got: 3",
+		},
+		{
+			name: "version 2 with extensions",
 			config: ProblemConfig{
 				Version:    "2",
+				ID:         "validation",
+				Title:      "Validation Failed",
+				StatusCode: 400,
+				Extensions: []Extension{
+					{Name: "errors", Type: "array", Required: true},
+					{Name: "errors[].field", Type: "string", Example: "email"},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "example with valid body",
+			config: ProblemConfig{
+				Version:    "1",
+				ID:         "404",
+				Title:      "Not Found",
+				StatusCode: 404,
+				Examples:   []Example{{Title: "Missing user", Body: `{"type": "/404", "status": 404}`}},
+			},
+			expectError: false,
+		},
+		{
+			name: "example missing body",
+			config: ProblemConfig{
+				Version:    "1",
 				ID:         "404",
 				Title:      "Not Found",
 				StatusCode: 404,
+				Examples:   []Example{{Title: "Missing user"}},
 			},
 			expectError: true,
-			errorMsg:    "problem configuration version must be \"1\", got: 2",
+			errorMsg:    "examples[0] missing required field: body",
+		},
+		{
+			name: "example with malformed body",
+			config: ProblemConfig{
+				Version:    "1",
+				ID:         "404",
+				Title:      "Not Found",
+				StatusCode: 404,
+				Examples:   []Example{{Body: `{"status": 404,}`}},
+			},
+			expectError: true,
+			errorMsg:    "examples[0] body is not valid JSON: invalid character '}' looking for beginning of object key string",
+		},
+		{
+			name: "example with different status",
+			config: ProblemConfig{
+				Version:    "2",
+				ID:         "404",
+				Title:      "Not Found",
+				StatusCode: 404,
+				Examples:   []Example{{Body: `{"status": 404}`}, {Body: `{"status": "410"}`}},
+			},
+			expectError: true,
+			errorMsg:    "examples[1] body status \"410\" differs from status_code 404",
+		},
+		{
+			name: "alias equal to ID",
+			config: ProblemConfig{
+				Version:    "1",
+				ID:         "404",
+				Aliases:    []string{"not-found", "404"},
+				Title:      "Not Found",
+				StatusCode: 404,
+			},
+			expectError: true,
+			errorMsg:    "aliases[1] equals the problem ID: 404",
+		},
+		{
+			name: "duplicate alias",
+			config: ProblemConfig{
+				Version:    "1",
+				ID:         "404",
+				Aliases:    []string{"not-found", "not-found"},
+				Title:      "Not Found",
+				StatusCode: 404,
+			},
+			expectError: true,
+			errorMsg:    "aliases[1] duplicates alias: not-found",
+		},
+		{
+			name: "deprecated with replacement",
+			config: ProblemConfig{
+				Version:         "1",
+				ID:              "users/gone",
+				Title:           "Gone",
+				StatusCode:      410,
+				Deprecated:      true,
+				DeprecatedSince: "2025-01-15",
+				Sunset:          "2026-06-30T00:00:00Z",
+				ReplacedBy:      "users/not-found",
+			},
+			expectError: false,
+		},
+		{
+			name: "sunset without deprecated",
+			config: ProblemConfig{
+				Version:    "1",
+				ID:         "users/gone",
+				Title:      "Gone",
+				StatusCode: 410,
+				Sunset:     "2026-06-30",
+			},
+			expectError: true,
+			errorMsg:    "problem configuration sunset requires deprecated to be true",
+		},
+		{
+			name: "malformed sunset",
+			config: ProblemConfig{
+				Version:    "1",
+				ID:         "users/gone",
+				Title:      "Gone",
+				StatusCode: 410,
+				Deprecated: true,
+				Sunset:     "30/06/2026",
+			},
+			expectError: true,
+			errorMsg:    "problem configuration sunset must be a date like 2025-06-30 or an RFC 3339 timestamp, got: 30/06/2026",
+		},
+		{
+			name: "replaced by itself",
+			config: ProblemConfig{
+				Version:    "1",
+				ID:         "users/gone",
+				Title:      "Gone",
+				StatusCode: 410,
+				Deprecated: true,
+				ReplacedBy: "users/gone",
+			},
+			expectError: true,
+			errorMsg:    "problem configuration replaced_by refers to the problem itself: users/gone",
+		},
+		{
+			name: "invalid tag",
+			config: ProblemConfig{
+				Version:    "1",
+				ID:         "404",
+				Title:      "Not Found",
+				StatusCode: 404,
+				Tags:       []string{"client", "Not Found"},
+			},
+			expectError: true,
+			errorMsg:    "tags[1] must consist of lowercase letters, digits and hyphens, got: Not Found",
+		},
+		{
+			name: "duplicate tag",
+			config: ProblemConfig{
+				Version:    "1",
+				ID:         "404",
+				Title:      "Not Found",
+				StatusCode: 404,
+				Category:   "Routing",
+				Tags:       []string{"client", "client"},
+			},
+			expectError: true,
+			errorMsg:    "tags[1] duplicates tag: client",
+		},
+		{
+			name: "extensions in version 1",
+			config: ProblemConfig{
+				Version:    "1",
+				ID:         "validation",
+				Title:      "Validation Failed",
+				StatusCode: 400,
+				Extensions: []Extension{{Name: "errors", Type: "array"}},
+			},
+			expectError: true,
+			errorMsg:    "problem configuration extensions require version \"2\"",
+		},
+		{
+			name: "extension missing name",
+			config: ProblemConfig{
+				Version:    "2",
+				ID:         "validation",
+				Title:      "Validation Failed",
+				StatusCode: 400,
+				Extensions: []Extension{{Type: "array"}},
+			},
+			expectError: true,
+			errorMsg:    "extensions[0] missing required field: name",
+		},
+		{
+			name: "extension with unsupported type",
+			config: ProblemConfig{
+				Version:    "2",
+				ID:         "validation",
+				Title:      "Validation Failed",
+				StatusCode: 400,
+				Extensions: []Extension{{Name: "errors", Type: "list"}},
+			},
+			expectError: true,
+			errorMsg:    "extensions[0] type must be one of string, number, integer, boolean, object, array, got: list",
+		},
+		{
+			name: "extension redefining standard member",
+			config: ProblemConfig{
+				Version:    "2",
+				ID:         "validation",
+				Title:      "Validation Failed",
+				StatusCode: 400,
+				Extensions: []Extension{{Name: "detail", Type: "string"}},
+			},
+			expectError: true,
+			errorMsg:    "extensions[0] redefines standard member: detail",
+		},
+		{
+			name: "duplicate extension",
+			config: ProblemConfig{
+				Version:    "2",
+				ID:         "validation",
+				Title:      "Validation Failed",
+				StatusCode: 400,
+				Extensions: []Extension{{Name: "errors", Type: "array"}, {Name: "errors", Type: "object"}},
+			},
+			expectError: true,
+			errorMsg:    "extensions[1] duplicates extension: errors",
 		},
 		{
 			name: "missing id",
@@ -91,6 +316,28 @@ func TestValidateProblemConfig(t *testing.T) {
 			expectError: true,
 			errorMsg:    "problem configuration missing required field: status_code",
 		},
+		{
+			name: "status_code below range",
+			config: ProblemConfig{
+				Version:    "1",
+				ID:         "42",
+				Title:      "Answer",
+				StatusCode: 42,
+			},
+			expectError: true,
+			errorMsg:    "status_code must be between 100 and 599, got: 42",
+		},
+		{
+			name: "status_code above range",
+			config: ProblemConfig{
+				Version:    "1",
+				ID:         "1000",
+				Title:      "Overflow",
+				StatusCode: 1000,
+			},
+			expectError: true,
+			errorMsg:    "status_code must be between 100 and 599, got: 1000",
+		},
 		{
 			name: "optional fields can be empty",
 			config: ProblemConfig{
@@ -185,13 +432,32 @@ links: []`,
 			errorMsg:    "document 1: duplicate problem ID found: 404",
 		},
 		{
-			name: "invalid version",
+			name: "version 2 with extensions",
 			fileContent: `version: "2"
+id: "validation"
+title: "Validation Failed"
+status_code: 400
+extensions:
+  - name: "errors"
+    type: "array"
+    required: true
+    description: "Violated constraints"
+    example:
+      - field: "email"
+        message: "must not be blank"
+  - name: "errors[].field"
+    type: "string"`,
+			expectError: false,
+			expectedIDs: []string{"validation"},
+		},
+		{
+			name: "invalid version",
+			fileContent: `version: "3"
 id: "404"
 title: "Not Found"
 status_code: 404`,
 			expectError: true,
-			errorMsg:    "document 0: problem configuration version must be \"1\", got: 2",
+			errorMsg:    "document 0: problem configuration version must be \"1\" or \"2\", got: 3",
 		},
 		{
 			name:        "empty file",
@@ -210,7 +476,7 @@ status_code: 404`,
 			}
 
 			registry := NewProblemRegistry()
-			err := registry.loadFile(tmpFile)
+			err := registry.loadFile(tmpFile, LoadOptions{})
 
 			if tt.expectError {
 				if err == nil {
@@ -235,7 +501,7 @@ status_code: 404`,
 	}
 }
 
-func TestProblemRegistry_GetAndGetAll(t *testing.T) {
+func TestProblemRegistry_Accessors(t *testing.T) {
 	registry := NewProblemRegistry()
 
 	config1 := &ProblemConfig{
@@ -243,6 +509,7 @@ func TestProblemRegistry_GetAndGetAll(t *testing.T) {
 		ID:         "404",
 		Title:      "Not Found",
 		StatusCode: 404,
+		Tags:       []string{"client"},
 	}
 	config2 := &ProblemConfig{
 		Version:    "1",
@@ -251,8 +518,19 @@ func TestProblemRegistry_GetAndGetAll(t *testing.T) {
 		StatusCode: 500,
 	}
 
-	registry.problems["404"] = config1
+	config3 := &ProblemConfig{
+		Version:    "1",
+		ID:         "custom/validation",
+		Name:       "Validation Failed",
+		Title:      "Bad Request",
+		StatusCode: 400,
+		Tags:       []string{"validation", "client"},
+	}
+
 	registry.problems["500"] = config2
+	registry.problems["404"] = config1
+	registry.problems["custom/validation"] = config3
+	registry.seal(7)
 
 	t.Run("Get existing", func(t *testing.T) {
 		config, exists := registry.Get("404")
@@ -271,13 +549,79 @@ func TestProblemRegistry_GetAndGetAll(t *testing.T) {
 		}
 	})
 
-	t.Run("GetAll", func(t *testing.T) {
-		all := registry.GetAll()
-		if len(all) != 2 {
-			t.Errorf("expected 2 configs but got %d", len(all))
+	t.Run("All", func(t *testing.T) {
+		all := map[string]*ProblemConfig{}
+		for id, p := range registry.All() {
+			all[id] = p
 		}
-		if all["404"] == nil || all["500"] == nil {
-			t.Errorf("expected both configs to be present")
+		if len(all) != 3 || registry.Len() != 3 {
+			t.Errorf("expected 3 configs but got %d", len(all))
+		}
+		if all["404"] == nil || all["500"] == nil || all["custom/validation"] == nil {
+			t.Errorf("expected all configs to be present")
+		}
+	})
+
+	t.Run("NearestAncestor", func(t *testing.T) {
+		tests := []struct {
+			id       string
+			expected string
+		}{
+			{"custom/validation/email/format", "custom/validation"},
+			{"custom/validation/", "custom/validation"},
+			{"404/users", "404"},
+			{"custom/other", ""},
+			{"custom/validation", ""},
+			{"404", ""},
+		}
+		for _, tt := range tests {
+			p, exists := registry.NearestAncestor(tt.id)
+			if tt.expected == "" {
+				if exists {
+					t.Errorf("expected no ancestor of %s but got %s", tt.id, p.ID)
+				}
+				continue
+			}
+			if !exists || p.ID != tt.expected {
+				t.Errorf("expected ancestor %s of %s but got %v", tt.expected, tt.id, p)
+			}
+		}
+	})
+
+	t.Run("Tags", func(t *testing.T) {
+		if tags := registry.Tags(); !slices.Equal(tags, []string{"client", "validation"}) {
+			t.Errorf("expected tags [client validation] but got %v", tags)
+		}
+
+		registry.Tags()[0] = "changed"
+		if tags := registry.Tags(); tags[0] != "client" {
+			t.Errorf("expected registry tags to be left intact but got %v", tags)
+		}
+	})
+
+	t.Run("Sorted", func(t *testing.T) {
+		var ids []string
+		for p := range registry.Sorted() {
+			ids = append(ids, p.ID)
+		}
+		expected := []string{"custom/validation", "404", "500"}
+		if len(ids) != len(expected) {
+			t.Fatalf("expected %v but got %v", expected, ids)
+		}
+		for i := range expected {
+			if ids[i] != expected[i] {
+				t.Errorf("expected %v but got %v", expected, ids)
+				break
+			}
+		}
+	})
+
+	t.Run("Generation", func(t *testing.T) {
+		if registry.Generation() != 7 {
+			t.Errorf("expected generation 7 but got %d", registry.Generation())
+		}
+		if registry.LoadedAt().IsZero() {
+			t.Errorf("expected load timestamp to be set")
 		}
 	})
 }
@@ -391,10 +735,249 @@ links: []`
 		}
 	})
 
+	t.Run("configmap volume layout", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		content := `version: "1"
+id: "404"
+title: "Not Found"
+status_code: 404`
+
+		dataDir := filepath.Join(tmpDir, "..2026_01_01_00_00_00.000000001")
+		if err := os.Mkdir(dataDir, 0755); err != nil {
+			t.Fatalf("failed to create data directory: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dataDir, "404.yaml"), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+		if err := os.Symlink(filepath.Base(dataDir), filepath.Join(tmpDir, "..data")); err != nil {
+			t.Fatalf("failed to create ..data symlink: %v", err)
+		}
+		if err := os.Symlink(filepath.Join("..data", "404.yaml"), filepath.Join(tmpDir, "404.yaml")); err != nil {
+			t.Fatalf("failed to create file symlink: %v", err)
+		}
+
+		registry, err := LoadFromDirectory(tmpDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(registry.problems) != 1 {
+			t.Errorf("expected 1 problem but got %d", len(registry.problems))
+		}
+	})
+
+	t.Run("configmap volume layout with nested directory", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		dataDir := filepath.Join(tmpDir, "..2025_01_01_00_00_00.000000001")
+		if err := os.MkdirAll(filepath.Join(dataDir, "httpcodes"), 0755); err != nil {
+			t.Fatalf("failed to create data directory: %v", err)
+		}
+		files := map[string]string{
+			"500.yaml":           "version: \"1\"\nid: \"500\"\ntitle: \"Internal Server Error\"\nstatus_code: 500",
+			"httpcodes/404.yaml": "version: \"1\"\nid: \"404\"\ntitle: \"Not Found\"\nstatus_code: 404",
+		}
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(dataDir, name), []byte(content), 0644); err != nil {
+				t.Fatalf("failed to create test file: %v", err)
+			}
+		}
+		// A symlink to the directory itself must not make the walk loop.
+		if err := os.Symlink(".", filepath.Join(dataDir, "httpcodes", "loop")); err != nil {
+			t.Fatalf("failed to create cyclic symlink: %v", err)
+		}
+
+		if err := os.Symlink(filepath.Base(dataDir), filepath.Join(tmpDir, "..data")); err != nil {
+			t.Fatalf("failed to create ..data symlink: %v", err)
+		}
+		for _, name := range []string{"500.yaml", "httpcodes"} {
+			if err := os.Symlink(filepath.Join("..data", name), filepath.Join(tmpDir, name)); err != nil {
+				t.Fatalf("failed to create symlink: %v", err)
+			}
+		}
+
+		registry, err := LoadFromDirectory(tmpDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(registry.problems) != 2 {
+			t.Fatalf("expected 2 problems but got %d", len(registry.problems))
+		}
+		root, _ := filepath.EvalSymlinks(tmpDir)
+		if source := registry.sources["404"]; source.path != filepath.Join(root, "httpcodes", "404.yaml") {
+			t.Errorf("expected 404 to be loaded through the symlink but got %s", source.path)
+		}
+	})
+
+	t.Run("symlinked directory", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		content := `version: "1"
+id: "404"
+title: "Not Found"
+status_code: 404`
+
+		targetDir := filepath.Join(tmpDir, "target")
+		if err := os.Mkdir(targetDir, 0755); err != nil {
+			t.Fatalf("failed to create target directory: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(targetDir, "404.yaml"), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+		linkDir := filepath.Join(tmpDir, "link")
+		if err := os.Symlink(targetDir, linkDir); err != nil {
+			t.Fatalf("failed to create directory symlink: %v", err)
+		}
+
+		registry, err := LoadFromDirectory(linkDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(registry.problems) != 1 {
+			t.Errorf("expected 1 problem but got %d", len(registry.problems))
+		}
+	})
+
+	t.Run("content digest and modification time", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		content := `version: "1"
+id: "404"
+title: "Not Found"
+status_code: 404`
+
+		file := filepath.Join(tmpDir, "404.yaml")
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+		modTime := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+		if err := os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatalf("failed to set modification time: %v", err)
+		}
+
+		first, err := LoadFromDirectory(tmpDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		second, err := LoadFromDirectory(tmpDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if first.Digest() == "" || first.Digest() != second.Digest() {
+			t.Errorf("expected equal, non-empty catalog digests but got %q and %q", first.Digest(), second.Digest())
+		}
+		if first.ProblemDigest("404") != second.ProblemDigest("404") {
+			t.Errorf("expected equal problem digests")
+		}
+		if !first.ModTime().Equal(modTime) || !first.ProblemModTime("404").Equal(modTime) {
+			t.Errorf("expected modification time %v but got %v", modTime, first.ModTime())
+		}
+
+		if err := os.WriteFile(file, []byte(content+"\nsummary: \"changed\""), 0644); err != nil {
+			t.Fatalf("failed to update test file: %v", err)
+		}
+		third, err := LoadFromDirectory(tmpDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if third.Digest() == first.Digest() {
+			t.Errorf("expected catalog digest to change with content")
+		}
+	})
+
+	t.Run("aliases", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			content1 string
+			content2 string
+			errorMsg string
+		}{
+			{
+				name:     "resolved across files",
+				content1: "version: \"1\"\nid: \"users/not-found\"\ntitle: \"Not Found\"\nstatus_code: 404\naliases: [\"user-not-found\", \"missing-user\"]",
+				content2: "version: \"1\"\nid: \"500\"\ntitle: \"Internal Server Error\"\nstatus_code: 500",
+			},
+			{
+				name:     "collision with problem ID",
+				content1: "version: \"1\"\nid: \"users/not-found\"\ntitle: \"Not Found\"\nstatus_code: 404\naliases: [\"500\"]",
+				content2: "version: \"1\"\nid: \"500\"\ntitle: \"Internal Server Error\"\nstatus_code: 500",
+				errorMsg: "alias 500 of problem users/not-found (%s) collides with problem ID defined in %s",
+			},
+			{
+				name:     "collision with other alias",
+				content1: "version: \"1\"\nid: \"users/not-found\"\ntitle: \"Not Found\"\nstatus_code: 404\naliases: [\"missing\"]",
+				content2: "version: \"1\"\nid: \"users/gone\"\ntitle: \"Gone\"\nstatus_code: 410\naliases: [\"missing\"]",
+				errorMsg: "alias missing of problem users/not-found (%s) is already an alias of problem users/gone (%s)",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tmpDir := t.TempDir()
+				file1 := filepath.Join(tmpDir, "1.yaml")
+				file2 := filepath.Join(tmpDir, "2.yaml")
+				if err := os.WriteFile(file1, []byte(tt.content1), 0644); err != nil {
+					t.Fatalf("failed to create test file: %v", err)
+				}
+				if err := os.WriteFile(file2, []byte(tt.content2), 0644); err != nil {
+					t.Fatalf("failed to create test file: %v", err)
+				}
+
+				registry, err := LoadFromDirectory(tmpDir)
+				if tt.errorMsg != "" {
+					loadErr, ok := err.(*LoadError)
+					if !ok || len(loadErr.Failures) != 1 {
+						t.Fatalf("expected a single load failure but got %v", err)
+					}
+					expected := fmt.Sprintf(tt.errorMsg, file1, file2)
+					if loadErr.Failures[0].Error() != expected {
+						t.Errorf("expected error %q but got %q", expected, loadErr.Failures[0].Error())
+					}
+					return
+				}
+
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				for _, alias := range []string{"user-not-found", "missing-user"} {
+					if p, exists := registry.ResolveAlias(alias); !exists || p.ID != "users/not-found" {
+						t.Errorf("expected alias %s to resolve to users/not-found", alias)
+					}
+				}
+				if _, exists := registry.ResolveAlias("users/not-found"); exists {
+					t.Errorf("expected problem ID not to resolve as an alias")
+				}
+			})
+		}
+	})
+
+	t.Run("unknown replacement", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		file := filepath.Join(tmpDir, "gone.yaml")
+		content := "version: \"1\"\nid: \"users/gone\"\ntitle: \"Gone\"\nstatus_code: 410\ndeprecated: true\nreplaced_by: \"users/missing\""
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		_, err := LoadFromDirectory(tmpDir)
+		loadErr, ok := err.(*LoadError)
+		if !ok || len(loadErr.Failures) != 1 {
+			t.Fatalf("expected a single load failure but got %v", err)
+		}
+		expected := fmt.Sprintf("problem users/gone (%s) is replaced by unknown problem: users/missing", file)
+		if loadErr.Failures[0].Error() != expected {
+			t.Errorf("expected error %q but got %q", expected, loadErr.Failures[0].Error())
+		}
+	})
+
 	t.Run("multiple invalid files", func(t *testing.T) {
 		tmpDir := t.TempDir()
 
-		invalidContent1 := `version: "2"
+		invalidContent1 := `version: "3"
 id: "404"
 title: "Not Found"
 status_code: 404`
@@ -0,0 +1,296 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package problems
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ProblemRegistry is a snapshot of loaded problem configurations. Once a
+// registry is handed out by LoadFromDirectory or a Store it is never modified,
+// so it can be read concurrently without locking. The returned ProblemConfig
+// values are shared between readers and must be treated as read-only.
+type ProblemRegistry struct {
+	problems   map[string]*ProblemConfig
+	sources    map[string]problemSource
+	aliases    map[string]string
+	sorted     []*ProblemConfig
+	tags       []string
+	digests    map[string]string
+	digest     string
+	modTime    time.Time
+	generation uint64
+	loadedAt   time.Time
+
+	// Tombstones are kept apart from problems, as they are never listed.
+	tombstones       map[string]*Tombstone
+	tombstoneSources map[string]problemSource
+}
+
+type problemSource struct {
+	path    string
+	modTime time.Time
+}
+
+func NewProblemRegistry() *ProblemRegistry {
+	return &ProblemRegistry{
+		problems: make(map[string]*ProblemConfig),
+		sources:  make(map[string]problemSource),
+		aliases:  make(map[string]string),
+
+		tombstones:       make(map[string]*Tombstone),
+		tombstoneSources: make(map[string]problemSource),
+	}
+}
+
+// sortedIDs returns IDs of all problems in lexical order, so that checks
+// spanning files report failures in a stable order.
+func (r *ProblemRegistry) sortedIDs() []string {
+	ids := make([]string, 0, len(r.problems))
+	for id := range r.problems {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// indexAliases maps aliases to the IDs of their problems, once all files are
+// loaded. An alias must not collide with a problem ID or with an alias of
+// another problem, in any of the files.
+func (r *ProblemRegistry) indexAliases() []error {
+	var failures []error
+	for _, id := range r.sortedIDs() {
+		for _, alias := range r.problems[id].Aliases {
+			if _, exists := r.problems[alias]; exists {
+				failures = append(failures, fmt.Errorf("alias %s of problem %s (%s) collides with problem ID defined in %s",
+					alias, id, r.sources[id].path, r.sources[alias].path))
+				continue
+			}
+			if other, exists := r.aliases[alias]; exists {
+				failures = append(failures, fmt.Errorf("alias %s of problem %s (%s) is already an alias of problem %s (%s)",
+					alias, id, r.sources[id].path, other, r.sources[other].path))
+				continue
+			}
+			r.aliases[alias] = id
+		}
+	}
+	return failures
+}
+
+// checkReplacements requires replaced_by of deprecated problems to refer to
+// an existing problem ID, in any of the files.
+func (r *ProblemRegistry) checkReplacements() []error {
+	var failures []error
+	for _, id := range r.sortedIDs() {
+		replacement := r.problems[id].ReplacedBy
+		if replacement == "" {
+			continue
+		}
+		if _, exists := r.problems[replacement]; !exists {
+			failures = append(failures, fmt.Errorf("problem %s (%s) is replaced by unknown problem: %s", id, r.sources[id].path, replacement))
+		}
+	}
+	return failures
+}
+
+// seal finishes loading by precomputing derived data and stamping the
+// registry with its generation and load timestamp.
+func (r *ProblemRegistry) seal(generation uint64) {
+	sorted := make([]*ProblemConfig, 0, len(r.problems))
+	for _, p := range r.problems {
+		sorted = append(sorted, p)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].StatusCode != sorted[j].StatusCode {
+			return sorted[i].StatusCode < sorted[j].StatusCode
+		}
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	tags := []string{}
+	for _, p := range sorted {
+		for _, tag := range p.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+
+	digests := make(map[string]string, len(r.problems))
+	catalog := sha256.New()
+	var modTime time.Time
+
+	for _, p := range sorted {
+		digests[p.ID] = digestProblem(p)
+		io.WriteString(catalog, p.ID+"\x00"+digests[p.ID]+"\n")
+
+		if source := r.sources[p.ID]; source.modTime.After(modTime) {
+			modTime = source.modTime
+		}
+	}
+
+	tombstoneIDs := make([]string, 0, len(r.tombstones))
+	for id := range r.tombstones {
+		tombstoneIDs = append(tombstoneIDs, id)
+	}
+	sort.Strings(tombstoneIDs)
+	for _, id := range tombstoneIDs {
+		data, _ := json.Marshal(r.tombstones[id])
+		io.WriteString(catalog, "tombstone\x00"+id+"\x00"+string(data)+"\n")
+	}
+
+	r.sorted = sorted
+	r.tags = tags
+	r.digests = digests
+	r.digest = fmt.Sprintf("%x", catalog.Sum(nil))
+	r.modTime = modTime
+	r.generation = generation
+	r.loadedAt = time.Now()
+}
+
+// carryModTime keeps ModTime from moving backwards across reloads. File
+// times alone can, e.g. when the newest file is deleted, so a changed catalog
+// is dated no earlier than its reload and an unchanged one keeps the time of
+// the registry it replaces.
+func (r *ProblemRegistry) carryModTime(previous *ProblemRegistry) {
+	since := previous.modTime
+	if r.digest != previous.digest {
+		since = r.loadedAt
+	}
+	if since.After(r.modTime) {
+		r.modTime = since
+	}
+}
+
+func (r *ProblemRegistry) Get(id string) (*ProblemConfig, bool) {
+	errConfig, exists := r.problems[id]
+	return errConfig, exists
+}
+
+// ResolveAlias returns the problem an alias (a former ID) refers to.
+func (r *ProblemRegistry) ResolveAlias(alias string) (*ProblemConfig, bool) {
+	id, exists := r.aliases[alias]
+	if !exists {
+		return nil, false
+	}
+	return r.Get(id)
+}
+
+// Tombstone returns the record of a problem removed from the catalog.
+func (r *ProblemRegistry) Tombstone(id string) (*Tombstone, bool) {
+	tombstone, exists := r.tombstones[id]
+	return tombstone, exists
+}
+
+// NearestAncestor walks up the "/"-separated segments of an ID to the
+// closest problem documented by ID or by alias, like "billing/card" and then
+// "billing" for "billing/card/expired". The ID itself is not considered.
+func (r *ProblemRegistry) NearestAncestor(id string) (*ProblemConfig, bool) {
+	for i := strings.LastIndex(id, "/"); i > 0; i = strings.LastIndex(id, "/") {
+		id = id[:i]
+		if p, exists := r.Get(id); exists {
+			return p, true
+		}
+		if p, exists := r.ResolveAlias(id); exists {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+func (r *ProblemRegistry) Len() int {
+	return len(r.problems)
+}
+
+// All iterates over problems keyed by their ID, in no particular order.
+func (r *ProblemRegistry) All() iter.Seq2[string, *ProblemConfig] {
+	return func(yield func(string, *ProblemConfig) bool) {
+		for id, p := range r.problems {
+			if !yield(id, p) {
+				return
+			}
+		}
+	}
+}
+
+// Sorted iterates over problems ordered by status code, then by name.
+func (r *ProblemRegistry) Sorted() iter.Seq[*ProblemConfig] {
+	return func(yield func(*ProblemConfig) bool) {
+		for _, p := range r.sorted {
+			if !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// Tags returns all tags used by problems, in lexical order. The slice is a
+// copy, so callers cannot alter the registry.
+func (r *ProblemRegistry) Tags() []string {
+	return slices.Clone(r.tags)
+}
+
+// Digest is a content hash of all problems, stable across restarts and
+// replicas as long as the problem configurations are the same.
+func (r *ProblemRegistry) Digest() string {
+	return r.digest
+}
+
+// ModTime returns the most recent modification time of the loaded files, or
+// of the reload that last changed the catalog, whichever is later.
+func (r *ProblemRegistry) ModTime() time.Time {
+	return r.modTime
+}
+
+// ProblemDigest is a content hash of a single problem configuration.
+func (r *ProblemRegistry) ProblemDigest(id string) string {
+	return r.digests[id]
+}
+
+// ProblemModTime returns the modification time of the file a problem was
+// loaded from.
+func (r *ProblemRegistry) ProblemModTime(id string) time.Time {
+	return r.sources[id].modTime
+}
+
+// Generation is incremented by the Store on every successful reload.
+func (r *ProblemRegistry) Generation() uint64 {
+	return r.generation
+}
+
+func (r *ProblemRegistry) LoadedAt() time.Time {
+	return r.loadedAt
+}
+
+func digestProblem(p *ProblemConfig) string {
+	h := sha256.New()
+	// Encoding of a plain struct never fails and is deterministic.
+	data, _ := json.Marshal(p)
+	h.Write(data)
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
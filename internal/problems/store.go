@@ -0,0 +1,145 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package problems
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Store holds the currently served ProblemRegistry and allows replacing it
+// with a freshly loaded one without interrupting readers.
+type Store struct {
+	dirPath  string
+	opts     LoadOptions
+	registry atomic.Pointer[ProblemRegistry]
+	reloadMu sync.Mutex
+}
+
+// ReloadReport describes the outcome of a Store.Reload call.
+type ReloadReport struct {
+	Success    bool     `json:"success"`
+	Generation uint64   `json:"generation"`
+	Before     int      `json:"before"`
+	After      int      `json:"after"`
+	Added      []string `json:"added"`
+	Removed    []string `json:"removed"`
+	Changed    []string `json:"changed"`
+	Errors     []string `json:"errors"`
+}
+
+func NewStore(dirPath string, opts LoadOptions) (*Store, error) {
+	registry, err := LoadFromDirectoryWithOptions(dirPath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &Store{dirPath: dirPath, opts: opts}
+	store.registry.Store(registry)
+	return store, nil
+}
+
+func (s *Store) Registry() *ProblemRegistry {
+	return s.registry.Load()
+}
+
+// Reload loads the problems directory again and swaps the served registry.
+// When loading fails, the previous registry is kept and the error is returned
+// along with a report listing every load failure.
+func (s *Store) Reload() (ReloadReport, error) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	previous := s.registry.Load()
+
+	registry, err := loadFromDirectory(s.dirPath, s.opts, previous.Generation()+1)
+	if err != nil {
+		log.Error().Err(err).Str("dir", s.dirPath).Msg("failed to reload problem configurations, keeping previous ones")
+		return ReloadReport{
+			Success:    false,
+			Generation: previous.Generation(),
+			Before:     previous.Len(),
+			After:      previous.Len(),
+			Added:      []string{},
+			Removed:    []string{},
+			Changed:    []string{},
+			Errors:     errorMessages(err),
+		}, err
+	}
+	registry.carryModTime(previous)
+
+	s.registry.Store(registry)
+
+	report := diffRegistries(previous, registry)
+	log.Info().
+		Str("dir", s.dirPath).
+		Uint64("generation", report.Generation).
+		Int("count", report.After).
+		Int("added", len(report.Added)).
+		Int("removed", len(report.Removed)).
+		Int("changed", len(report.Changed)).
+		Msg("reloaded problem configurations")
+	return report, nil
+}
+
+func diffRegistries(previous *ProblemRegistry, current *ProblemRegistry) ReloadReport {
+	report := ReloadReport{
+		Success:    true,
+		Generation: current.Generation(),
+		Before:     previous.Len(),
+		After:      current.Len(),
+		Added:      []string{},
+		Removed:    []string{},
+		Changed:    []string{},
+		Errors:     []string{},
+	}
+
+	for id, problem := range current.problems {
+		old, exists := previous.problems[id]
+		if !exists {
+			report.Added = append(report.Added, id)
+		} else if !reflect.DeepEqual(old, problem) {
+			report.Changed = append(report.Changed, id)
+		}
+	}
+	for id := range previous.problems {
+		if _, exists := current.problems[id]; !exists {
+			report.Removed = append(report.Removed, id)
+		}
+	}
+
+	sort.Strings(report.Added)
+	sort.Strings(report.Removed)
+	sort.Strings(report.Changed)
+	return report
+}
+
+func errorMessages(err error) []string {
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) {
+		return []string{err.Error()}
+	}
+
+	messages := make([]string, 0, len(loadErr.Failures))
+	for _, failure := range loadErr.Failures {
+		messages = append(messages, failure.Error())
+	}
+	return messages
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package problems
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const storeTestProblem404 = `version: "1"
+id: "404"
+title: "Not Found"
+status_code: 404`
+
+const storeTestProblem500 = `version: "1"
+id: "500"
+title: "Internal Server Error"
+status_code: 500`
+
+func TestStore_Reload(t *testing.T) {
+	t.Run("swaps registry on successful reload", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeTestFile(t, filepath.Join(tmpDir, "404.yaml"), storeTestProblem404)
+
+		store, err := NewStore(tmpDir, LoadOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		writeTestFile(t, filepath.Join(tmpDir, "500.yaml"), storeTestProblem500)
+
+		report, err := store.Reload()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, exists := store.Registry().Get("500"); !exists {
+			t.Errorf("expected reloaded registry to contain 500")
+		}
+		if !report.Success || report.Generation != 2 || report.Before != 1 || report.After != 2 {
+			t.Errorf("unexpected report: %+v", report)
+		}
+		if len(report.Added) != 1 || report.Added[0] != "500" {
+			t.Errorf("expected 500 to be reported as added, got %v", report.Added)
+		}
+	})
+
+	t.Run("reports removed and changed problems", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeTestFile(t, filepath.Join(tmpDir, "404.yaml"), storeTestProblem404)
+		writeTestFile(t, filepath.Join(tmpDir, "500.yaml"), storeTestProblem500)
+
+		store, err := NewStore(tmpDir, LoadOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := os.Remove(filepath.Join(tmpDir, "500.yaml")); err != nil {
+			t.Fatalf("failed to remove test file: %v", err)
+		}
+		writeTestFile(t, filepath.Join(tmpDir, "404.yaml"), storeTestProblem404+"\nsummary: \"changed\"")
+
+		report, err := store.Reload()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(report.Removed) != 1 || report.Removed[0] != "500" {
+			t.Errorf("expected 500 to be reported as removed, got %v", report.Removed)
+		}
+		if len(report.Changed) != 1 || report.Changed[0] != "404" {
+			t.Errorf("expected 404 to be reported as changed, got %v", report.Changed)
+		}
+	})
+
+	t.Run("advances mod time when the newest file is removed", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeTestFile(t, filepath.Join(tmpDir, "404.yaml"), storeTestProblem404)
+		writeTestFile(t, filepath.Join(tmpDir, "500.yaml"), storeTestProblem500)
+
+		older := time.Now().Add(-2 * time.Hour)
+		if err := os.Chtimes(filepath.Join(tmpDir, "404.yaml"), older, older); err != nil {
+			t.Fatalf("failed to set mod time: %v", err)
+		}
+
+		store, err := NewStore(tmpDir, LoadOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		before := store.Registry().ModTime()
+
+		if err := os.Remove(filepath.Join(tmpDir, "500.yaml")); err != nil {
+			t.Fatalf("failed to remove test file: %v", err)
+		}
+		if _, err := store.Reload(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if after := store.Registry().ModTime(); after.Before(before) {
+			t.Errorf("expected mod time not to move back from %v, got %v", before, after)
+		}
+	})
+
+	t.Run("keeps mod time when the catalog is unchanged", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeTestFile(t, filepath.Join(tmpDir, "404.yaml"), storeTestProblem404)
+
+		store, err := NewStore(tmpDir, LoadOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		before := store.Registry().ModTime()
+
+		if _, err := store.Reload(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if after := store.Registry().ModTime(); !after.Equal(before) {
+			t.Errorf("expected mod time %v, got %v", before, after)
+		}
+	})
+
+	t.Run("keeps previous registry on failed reload", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeTestFile(t, filepath.Join(tmpDir, "404.yaml"), storeTestProblem404)
+
+		store, err := NewStore(tmpDir, LoadOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		previous := store.Registry()
+
+		writeTestFile(t, filepath.Join(tmpDir, "broken1.yaml"), `version: "1"`)
+		writeTestFile(t, filepath.Join(tmpDir, "broken2.yaml"), `version: "1"`)
+
+		report, err := store.Reload()
+		if err == nil {
+			t.Errorf("expected error for invalid file")
+		}
+		if report.Success || len(report.Errors) != 2 {
+			t.Errorf("expected report with 2 errors, got %+v", report)
+		}
+
+		if store.Registry() != previous {
+			t.Errorf("expected previous registry to be kept")
+		}
+	})
+}
+
+func TestWatcher_Run(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestFile(t, filepath.Join(tmpDir, "404.yaml"), storeTestProblem404)
+
+	store, err := NewStore(tmpDir, LoadOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watcher := NewWatcher(store, 10*time.Millisecond, 20*time.Millisecond)
+	go watcher.Run(ctx)
+
+	nested := filepath.Join(tmpDir, "nested", "dir")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("failed to create nested directory: %v", err)
+	}
+	writeTestFile(t, filepath.Join(nested, "500.yaml"), storeTestProblem500)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, exists := store.Registry().Get("500"); exists {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("expected watcher to reload registry with nested file")
+}
+
+func TestFingerprintDirectory_SymlinkSwap(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	first := filepath.Join(tmpDir, "..first")
+	second := filepath.Join(tmpDir, "..second")
+	for _, dir := range []string{first, second} {
+		if err := os.Mkdir(dir, 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		writeTestFile(t, filepath.Join(dir, "404.yaml"), storeTestProblem404)
+	}
+
+	modTime := time.Now().Add(-time.Hour)
+	for _, dir := range []string{first, second} {
+		if err := os.Chtimes(filepath.Join(dir, "404.yaml"), modTime, modTime); err != nil {
+			t.Fatalf("failed to set modification time: %v", err)
+		}
+	}
+
+	dataLink := filepath.Join(tmpDir, "..data")
+	if err := os.Symlink(filepath.Base(first), dataLink); err != nil {
+		t.Fatalf("failed to create ..data symlink: %v", err)
+	}
+	if err := os.Symlink(filepath.Join("..data", "404.yaml"), filepath.Join(tmpDir, "404.yaml")); err != nil {
+		t.Fatalf("failed to create file symlink: %v", err)
+	}
+
+	before, err := fingerprintDirectory(tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := os.Remove(dataLink); err != nil {
+		t.Fatalf("failed to remove ..data symlink: %v", err)
+	}
+	if err := os.Symlink(filepath.Base(second), dataLink); err != nil {
+		t.Fatalf("failed to create ..data symlink: %v", err)
+	}
+
+	after, err := fingerprintDirectory(tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if before == after {
+		t.Errorf("expected fingerprint to change after symlink swap")
+	}
+}
+
+func writeTestFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+}
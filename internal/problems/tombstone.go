@@ -0,0 +1,125 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package problems
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/rs/zerolog/log"
+)
+
+// Tombstone records a problem removed from the catalog, so that its type URI
+// keeps answering with 410 Gone instead of a generic 404. Documents with a
+// removed field are tombstones, and live next to problem configurations.
+type Tombstone struct {
+	Version    string `yaml:"version" json:"version"`
+	ID         string `yaml:"id" json:"id"`
+	Removed    string `yaml:"removed" json:"removed"`
+	Reason     string `yaml:"reason" json:"reason"`
+	ReplacedBy string `yaml:"replaced_by" json:"replaced_by,omitempty"`
+}
+
+// isTombstone tells tombstones apart from problem configurations by the
+// removed field.
+func isTombstone(body ast.Node) bool {
+	path, err := yaml.PathString("$.removed")
+	if err != nil {
+		return false
+	}
+	node, err := path.FilterNode(body)
+	return err == nil && node != nil
+}
+
+// checkTombstone returns all validation failures of a tombstone. Whether its
+// ID collides with a live problem, and whether replaced_by refers to one, is
+// checked once all files are loaded.
+func checkTombstone(tombstone *Tombstone) []violation {
+	var violations []violation
+	if tombstone.Version != "1" {
+		violations = append(violations, violation{"version", fmt.Errorf("tombstone version must be \"1\", got: %s", tombstone.Version)})
+	}
+	if tombstone.ID == "" {
+		violations = append(violations, violation{"id", fmt.Errorf("tombstone missing required field: id")})
+	}
+	if _, err := ParseDate(tombstone.Removed); err != nil {
+		violations = append(violations, violation{"removed", fmt.Errorf("tombstone removed must be a date like 2025-06-30 or an RFC 3339 timestamp, got: %s", tombstone.Removed)})
+	}
+	if tombstone.Reason == "" {
+		violations = append(violations, violation{"reason", fmt.Errorf("tombstone missing required field: reason")})
+	}
+	if tombstone.ReplacedBy != "" && tombstone.ReplacedBy == tombstone.ID {
+		violations = append(violations, violation{"replaced_by", fmt.Errorf("tombstone replaced_by refers to the removed problem itself: %s", tombstone.ReplacedBy)})
+	}
+	return violations
+}
+
+func (r *ProblemRegistry) loadTombstone(body ast.Node, filePath string, modTime time.Time, docIndex int, opts LoadOptions) error {
+	var tombstone Tombstone
+	if err := yaml.NodeToValue(body, &tombstone); err != nil {
+		return fmt.Errorf("failed to parse YAML document %d: %w", docIndex, err)
+	}
+
+	if err := checkUnknownFields(body, reflect.TypeFor[Tombstone](), filePath, docIndex, opts); err != nil {
+		return err
+	}
+
+	if violations := checkTombstone(&tombstone); len(violations) > 0 {
+		return fmt.Errorf("document %d: %w", docIndex, violations[0].err)
+	}
+
+	if _, exists := r.tombstones[tombstone.ID]; exists {
+		return fmt.Errorf("document %d: duplicate tombstone ID found: %s", docIndex, tombstone.ID)
+	}
+
+	r.tombstones[tombstone.ID] = &tombstone
+	r.tombstoneSources[tombstone.ID] = problemSource{path: filePath, modTime: modTime}
+	log.Debug().Str("id", tombstone.ID).Str("file", filePath).Int("document", docIndex).Msg("loaded tombstone")
+	return nil
+}
+
+// checkTombstones refuses tombstones of problems that are still defined,
+// either by ID or by alias, and requires replaced_by to refer to an existing
+// problem ID, in any of the files.
+func (r *ProblemRegistry) checkTombstones() []error {
+	var failures []error
+	ids := make([]string, 0, len(r.tombstones))
+	for id := range r.tombstones {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		path := r.tombstoneSources[id].path
+		if _, exists := r.problems[id]; exists {
+			failures = append(failures, fmt.Errorf("tombstone %s (%s) collides with problem ID defined in %s", id, path, r.sources[id].path))
+			continue
+		}
+		if owner, exists := r.aliases[id]; exists {
+			failures = append(failures, fmt.Errorf("tombstone %s (%s) collides with an alias of problem %s (%s)", id, path, owner, r.sources[owner].path))
+			continue
+		}
+		if replacement := r.tombstones[id].ReplacedBy; replacement != "" {
+			if _, exists := r.problems[replacement]; !exists {
+				failures = append(failures, fmt.Errorf("tombstone %s (%s) is replaced by unknown problem: %s", id, path, replacement))
+			}
+		}
+	}
+	return failures
+}
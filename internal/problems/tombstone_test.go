@@ -0,0 +1,154 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package problems
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const liveProblem = `version: "1"
+id: "users/not-found"
+aliases: ["missing-user"]
+title: "Not Found"
+status_code: 404
+summary: "Not found"
+description: "User not found"`
+
+func TestLoadFromDirectory_Tombstones(t *testing.T) {
+	tests := []struct {
+		name      string
+		tombstone string
+		errorMsg  string
+	}{
+		{
+			name:      "valid",
+			tombstone: "version: \"1\"\nid: \"users/gone\"\nremoved: \"2025-03-01\"\nreason: \"Merged into not found.\"\nreplaced_by: \"users/not-found\"",
+		},
+		{
+			name:      "missing reason",
+			tombstone: "version: \"1\"\nid: \"users/gone\"\nremoved: \"2025-03-01\"",
+			errorMsg:  "failed to load %[1]s: document 0: tombstone missing required field: reason",
+		},
+		{
+			name:      "malformed removal date",
+			tombstone: "version: \"1\"\nid: \"users/gone\"\nremoved: \"March 2025\"\nreason: \"Obsolete.\"",
+			errorMsg:  "failed to load %[1]s: document 0: tombstone removed must be a date like 2025-06-30 or an RFC 3339 timestamp, got: March 2025",
+		},
+		{
+			name:      "collision with problem ID",
+			tombstone: "version: \"1\"\nid: \"users/not-found\"\nremoved: \"2025-03-01\"\nreason: \"Obsolete.\"",
+			errorMsg:  "tombstone users/not-found (%[1]s) collides with problem ID defined in %[2]s",
+		},
+		{
+			name:      "collision with alias",
+			tombstone: "version: \"1\"\nid: \"missing-user\"\nremoved: \"2025-03-01\"\nreason: \"Obsolete.\"",
+			errorMsg:  "tombstone missing-user (%[1]s) collides with an alias of problem users/not-found (%[2]s)",
+		},
+		{
+			name:      "unknown replacement",
+			tombstone: "version: \"1\"\nid: \"users/gone\"\nremoved: \"2025-03-01\"\nreason: \"Obsolete.\"\nreplaced_by: \"users/missing\"",
+			errorMsg:  "tombstone users/gone (%[1]s) is replaced by unknown problem: users/missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			tombstoneFile := filepath.Join(tmpDir, "removed.yaml")
+			problemFile := filepath.Join(tmpDir, "users.yaml")
+			if err := os.WriteFile(tombstoneFile, []byte(tt.tombstone), 0644); err != nil {
+				t.Fatalf("failed to create test file: %v", err)
+			}
+			if err := os.WriteFile(problemFile, []byte(liveProblem), 0644); err != nil {
+				t.Fatalf("failed to create test file: %v", err)
+			}
+
+			registry, err := LoadFromDirectoryWithOptions(tmpDir, LoadOptions{UnknownFields: UnknownFieldsError})
+			if tt.errorMsg != "" {
+				loadErr, ok := err.(*LoadError)
+				if !ok || len(loadErr.Failures) != 1 {
+					t.Fatalf("expected a single load failure but got %v", err)
+				}
+				expected := fmt.Sprintf(tt.errorMsg, tombstoneFile, problemFile)
+				if loadErr.Failures[0].Error() != expected {
+					t.Errorf("expected error %q but got %q", expected, loadErr.Failures[0].Error())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tombstone, exists := registry.Tombstone("users/gone")
+			if !exists || tombstone.Removed != "2025-03-01" || tombstone.ReplacedBy != "users/not-found" {
+				t.Errorf("unexpected tombstone: %+v", tombstone)
+			}
+			if _, exists := registry.Get("users/gone"); exists {
+				t.Errorf("expected tombstone not to be loaded as a problem")
+			}
+			if registry.Len() != 1 {
+				t.Errorf("expected 1 problem but got %d", registry.Len())
+			}
+		})
+	}
+}
+
+func TestLint_Tombstones(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"users.yaml": liveProblem,
+		"removed.yaml": `version: "1"
+id: "users/not-found"
+removed: "2025-03-01"
+reason: "Obsolete."
+---
+version: "1"
+id: "users/gone"
+removed: "2025-03-01"
+reason: "Obsolete."
+replaced_by: "users/missing"`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	result, err := Lint(tmpDir, LoadOptions{UnknownFields: UnknownFieldsError})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		line    int
+		rule    string
+		message string
+	}{
+		{10, "unknown-replacement", "problem users/gone is replaced by unknown problem: users/missing"},
+		{2, "tombstone-collision", fmt.Sprintf("tombstone users/not-found collides with problem ID (defined in %s:2)", filepath.Join(tmpDir, "users.yaml"))},
+	}
+	if len(result.Diagnostics) != len(expected) {
+		t.Fatalf("expected %d diagnostics but got %+v", len(expected), result.Diagnostics)
+	}
+	for i, e := range expected {
+		d := result.Diagnostics[i]
+		if filepath.Base(d.File) != "removed.yaml" || d.Line != e.line || d.Rule != e.rule || d.Message != e.message {
+			t.Errorf("expected removed.yaml:%d %s %q but got %+v", e.line, e.rule, e.message, d)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package problems
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Watcher periodically checks the problems directory of a Store and triggers
+// a reload whenever any of the YAML files are added, removed or modified.
+//
+// Changes are debounced: a reload happens only once the directory has not
+// changed for the debounce period, so bursts of writes (or a ConfigMap
+// symlink swap, which touches several entries) result in a single reload.
+type Watcher struct {
+	store    *Store
+	interval time.Duration
+	debounce time.Duration
+	baseline string
+}
+
+// NewWatcher takes the current state of the directory as the baseline, so
+// that only changes made after this call cause a reload.
+func NewWatcher(store *Store, interval time.Duration, debounce time.Duration) *Watcher {
+	baseline, err := fingerprintDirectory(store.dirPath)
+	if err != nil {
+		log.Warn().Err(err).Str("dir", store.dirPath).Msg("failed to fingerprint problems directory")
+	}
+	return &Watcher{store: store, interval: interval, debounce: debounce, baseline: baseline}
+}
+
+// Run blocks until ctx is cancelled.
+func (w *Watcher) Run(ctx context.Context) {
+	applied := w.baseline
+	observed := applied
+	var changedAt time.Time
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	log.Info().
+		Str("dir", w.store.dirPath).
+		Dur("interval", w.interval).
+		Dur("debounce", w.debounce).
+		Msg("watching problems directory for changes")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current, err := fingerprintDirectory(w.store.dirPath)
+		if err != nil {
+			log.Warn().Err(err).Str("dir", w.store.dirPath).Msg("failed to fingerprint problems directory")
+			continue
+		}
+
+		if current != observed {
+			observed = current
+			changedAt = time.Now()
+			log.Debug().Str("dir", w.store.dirPath).Msg("detected change in problems directory")
+			continue
+		}
+
+		if observed == applied || time.Since(changedAt) < w.debounce {
+			continue
+		}
+		applied = observed
+
+		log.Info().Str("dir", w.store.dirPath).Msg("problems directory changed, reloading")
+		_, _ = w.store.Reload()
+	}
+}
+
+// fingerprintDirectory computes a digest of names, sizes and modification times
+// of all YAML files that LoadFromDirectory would pick up. Symlinks are resolved,
+// so that swapping their targets is noticed even when file metadata matches.
+func fingerprintDirectory(dirPath string) (string, error) {
+	h := sha256.New()
+
+	err := walkProblemFiles(dirPath, func(path string, err error) error {
+		if err != nil {
+			return err
+		}
+
+		target, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			return err
+		}
+
+		info, err := os.Stat(target)
+		if err != nil {
+			return err
+		}
+
+		io.WriteString(h, fmt.Sprintf("%s\x00%s\x00%d\x00%d\n", path, target, info.Size(), info.ModTime().UnixNano()))
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
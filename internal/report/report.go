@@ -0,0 +1,263 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+// Formats lists supported output formats of lint results.
+var Formats = []string{"text", "json", "sarif", "junit"}
+
+// Write encodes lint results in the given format. The version is reported as
+// the version of the tool where the format supports it.
+func Write(w io.Writer, format string, version string, result *problems.LintResult) error {
+	switch format {
+	case "text":
+		return writeText(w, result)
+	case "json":
+		return writeJSON(w, result)
+	case "sarif":
+		return writeSARIF(w, version, result)
+	case "junit":
+		return writeJUnit(w, result)
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+func location(d problems.Diagnostic) string {
+	if d.Line == 0 {
+		return d.File
+	}
+	return fmt.Sprintf("%s:%d:%d", d.File, d.Line, d.Column)
+}
+
+func writeText(w io.Writer, result *problems.LintResult) error {
+	for _, d := range result.Diagnostics {
+		if _, err := fmt.Fprintf(w, "%s: %s: %s (%s)\n", location(d), d.Severity, d.Message, d.Rule); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d error(s), %d warning(s) in %d file(s)\n",
+		result.Count(problems.SeverityError), result.Count(problems.SeverityWarning), len(result.Files))
+	return err
+}
+
+func writeJSON(w io.Writer, result *problems.LintResult) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		*problems.LintResult
+		Errors   int `json:"errors"`
+		Warnings int `json:"warnings"`
+	}{
+		LintResult: result,
+		Errors:     result.Count(problems.SeverityError),
+		Warnings:   result.Count(problems.SeverityWarning),
+	})
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func writeSARIF(w io.Writer, version string, result *problems.LintResult) error {
+	ruleIDs := map[string]bool{}
+	results := []sarifResult{}
+
+	for _, d := range result.Diagnostics {
+		ruleIDs[d.Rule] = true
+
+		physical := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(d.File)}}
+		if d.Line > 0 {
+			physical.Region = &sarifRegion{StartLine: d.Line, StartColumn: d.Column}
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    d.Rule,
+			Level:     string(d.Severity),
+			Message:   sarifMessage{Text: d.Message},
+			Locations: []sarifLocation{{PhysicalLocation: physical}},
+		})
+	}
+
+	rules := []sarifRule{}
+	for id := range ruleIDs {
+		rules = append(rules, sarifRule{ID: id})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "failbook",
+				Version:        version,
+				InformationURI: "https://github.com/malczuuu/failbook",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	})
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut *junitOutput  `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+type junitOutput struct {
+	Text string `xml:",cdata"`
+}
+
+// writeJUnit reports every linted file as a test case, failed if it has any
+// errors. Warnings are attached as test output.
+func writeJUnit(w io.Writer, result *problems.LintResult) error {
+	byFile := map[string][]problems.Diagnostic{}
+	for _, d := range result.Diagnostics {
+		byFile[d.File] = append(byFile[d.File], d)
+	}
+
+	suite := junitTestSuite{Name: "failbook lint", Tests: len(result.Files)}
+	for _, file := range result.Files {
+		testCase := junitTestCase{ClassName: "failbook.lint", Name: filepath.ToSlash(file)}
+
+		var errors, warnings []string
+		for _, d := range byFile[file] {
+			line := fmt.Sprintf("%s: %s (%s)", location(d), d.Message, d.Rule)
+			if d.Severity == problems.SeverityError {
+				errors = append(errors, line)
+			} else {
+				warnings = append(warnings, line)
+			}
+		}
+
+		if len(errors) > 0 {
+			suite.Failures++
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d error(s)", len(errors)),
+				Type:    string(problems.SeverityError),
+				Text:    strings.Join(errors, "\n"),
+			}
+		}
+		if len(warnings) > 0 {
+			testCase.SystemOut = &junitOutput{Text: strings.Join(warnings, "\n")}
+		}
+
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{
+		Name:     suite.Name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Damian Malczewski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// SPDX-License-Identifier: MIT
+
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/malczuuu/failbook/internal/problems"
+)
+
+var testResult = &problems.LintResult{
+	Files: []string{"docs/a.yaml", "docs/b.yaml"},
+	Diagnostics: []problems.Diagnostic{
+		{File: "docs/a.yaml", Line: 3, Column: 5, Severity: problems.SeverityError, Rule: "duplicate-id", Message: "duplicate problem ID found: 404"},
+		{File: "docs/a.yaml", Line: 1, Column: 1, Severity: problems.SeverityWarning, Rule: "missing-summary", Message: "summary is empty"},
+	},
+}
+
+func TestWrite_Text(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, "text", "1.0.0", testResult); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `docs/a.yaml:3:5: error: duplicate problem ID found: 404 (duplicate-id)
+docs/a.yaml:1:1: warning: summary is empty (missing-summary)
+1 error(s), 1 warning(s) in 2 file(s)
+`
+	if buf.String() != expected {
+		t.Errorf("expected %q but got %q", expected, buf.String())
+	}
+}
+
+func TestWrite_SARIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, "sarif", "1.0.0", testResult); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("failed to decode SARIF: %v", err)
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected SARIF log: %+v", log)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results but got %d", len(results))
+	}
+	region := results[0].Locations[0].PhysicalLocation.Region
+	if results[0].Level != "error" || region == nil || region.StartLine != 3 || region.StartColumn != 5 {
+		t.Errorf("unexpected result: %+v", results[0])
+	}
+	if len(log.Runs[0].Tool.Driver.Rules) != 2 {
+		t.Errorf("expected 2 rules but got %d", len(log.Runs[0].Tool.Driver.Rules))
+	}
+}
+
+func TestWrite_JUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, "junit", "1.0.0", testResult); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("failed to decode JUnit XML: %v", err)
+	}
+
+	if suites.Tests != 2 || suites.Failures != 1 {
+		t.Errorf("expected 2 tests with 1 failure but got %d with %d", suites.Tests, suites.Failures)
+	}
+	cases := suites.Suites[0].Cases
+	if cases[0].Failure == nil || !strings.Contains(cases[0].Failure.Text, "docs/a.yaml:3:5") {
+		t.Errorf("expected failure for docs/a.yaml")
+	}
+	if cases[1].Failure != nil {
+		t.Errorf("expected docs/b.yaml to pass")
+	}
+}
+
+func TestWrite_UnsupportedFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, "html", "", testResult); err == nil {
+		t.Errorf("expected error for unsupported format")
+	}
+}